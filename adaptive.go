@@ -0,0 +1,59 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/go-ng/sort"
+)
+
+// adaptiveAbortMovesFactor is the multiplier of `n*log2(n)` defining
+// how many element moves AppendedAdaptiveAbort allows before it gives up
+// on the group-insert strategy.
+const adaptiveAbortMovesFactor = 2
+
+// AppendedAdaptiveAbort is the same as Appended, but instead of deciding
+// the strategy upfront it always starts the group-insert and counts the
+// moved elements. If the amount of moves exceeds `2*n*log2(n)` (which
+// happens if the input turns out to be pathological for this strategy, see
+// the `k^2` term of Appended), it bails out to a full `sort.Sort`.
+//
+// This protects against mis-estimated tails causing quadratic blowups.
+// OnStrategy receives StrategyGroupInsert (the strategy it starts with)
+// even if it bails out later.
+//
+// T: O(min(k*ln(n) + n + k^2, n*ln(n)))
+//
+// S: O(1) [if without `s`]
+func AppendedAdaptiveAbort[E any, S Interface[E]](s S, tailLength uint) {
+	strategy := startAppended(s, tailLength, appendedPolicy{shouldUse: alwaysUseAppended})
+	if strategy != StrategyGroupInsert {
+		finishAppended(s, tailLength, strategy)
+		return
+	}
+
+	splitIdx := uint(len(s)) - tailLength
+	sortTailDescending(s[splitIdx:])
+	if !groupInsertDescendingTailWithBudget(s, splitIdx, adaptiveAbortMovesBudget(len(s))) {
+		sort.Sort(s)
+	}
+}
+
+// adaptiveAbortMovesBudget returns the maximal amount of element moves
+// AppendedAdaptiveAbort allows for a slice of the given length.
+func adaptiveAbortMovesBudget(length int) int {
+	return nLogNBudget(length, adaptiveAbortMovesFactor)
+}
+
+// nLogNBudget returns `factor*n*log2(n)` saturated to math.MaxInt.
+func nLogNBudget(length, factor int) int {
+	logLength := bits.Len(uint(length))
+	if length > math.MaxInt/factor/logLength {
+		return math.MaxInt
+	}
+	return factor * length * logLength
+}
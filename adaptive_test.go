@@ -0,0 +1,122 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+func testAppendedAdaptiveAbort(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		AppendedAdaptiveAbort(intSlice(s), tailLenght)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedAdaptiveAbort(t *testing.T) {
+	testAppendedAdaptiveAbort(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedAdaptiveAbort(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedAdaptiveAbort(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 14, 12, 8, 1}, 4)
+	testAppendedAdaptiveAbort(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 16, 18, 20, 21}, 4)
+	testAppendedAdaptiveAbort(t, []byte{5, 4, 3, 2, 1}, 5)
+
+	t.Run("budget_exceeded", func(t *testing.T) {
+		// the tail values interleave with the whole prefix, so each of them
+		// requires to move many elements.
+		s := make(intSlice, 0, 64)
+		for v := 0; v < 32; v++ {
+			s = append(s, v*2)
+		}
+		for v := 0; v < 32; v++ {
+			s = append(s, v*2+1)
+		}
+		c := make([]int, len(s))
+		copy(c, s)
+		sortTailDescending(s[32:])
+		if groupInsertDescendingTailWithBudget(s, 32, 64) {
+			t.Fatal("expected the budget to be exceeded")
+		}
+		stdsort.Ints(s)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("the elements were lost: %v != %v", c, s)
+		}
+	})
+
+	t.Run("budget_not_exceeded", func(t *testing.T) {
+		s := intSlice{1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 21, 23, 25, 27, 29, 31, 10, 2}
+		if !groupInsertDescendingTailWithBudget(s, 16, adaptiveAbortMovesBudget(len(s))) {
+			t.Fatal("expected the budget not to be exceeded")
+		}
+		if !stdsort.IntsAreSorted(s) {
+			t.Fatalf("not sorted: %v", s)
+		}
+	})
+}
+
+func FuzzAppendedAdaptiveAbort(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedAdaptiveAbort(t, initial, tailLenght)
+	})
+}
+
+func BenchmarkAppendedAdaptiveAbort(b *testing.B) {
+	const (
+		totalSize = 65536
+		csCount   = 20
+	)
+	for _, tailSize := range []int{16, 1024, 16384} {
+		rng := rand.New(rand.NewSource(0))
+		in := make([][]int, csCount)
+		for idx := range in {
+			in[idx] = make([]int, totalSize)
+			s := in[idx]
+			for idx := range s {
+				s[idx] = rng.Intn(totalSize)
+			}
+			stdsort.Ints(s[:totalSize-tailSize])
+		}
+
+		cs := make([]intSlice, csCount)
+		for idx := range cs {
+			cs[idx] = make([]int, totalSize)
+		}
+
+		for _, f := range []struct {
+			name string
+			fn   func(intSlice, uint)
+		}{
+			{name: "Appended", fn: Appended[int, intSlice]},
+			{name: "AppendedAdaptiveAbort", fn: AppendedAdaptiveAbort[int, intSlice]},
+		} {
+			b.Run(fmt.Sprintf("total-%d/tail-%d/%s", totalSize, tailSize, f.name), func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range cs {
+							copy(cs[idx], in[idx])
+						}
+						b.StartTimer()
+					}
+					f.fn(cs[idx], uint(tailSize))
+				}
+			})
+		}
+	}
+}
@@ -6,8 +6,9 @@ package xsort
 
 import (
 	"fmt"
+	"math"
+	"math/bits"
 
-	"github.com/go-ng/slices"
 	"github.com/go-ng/sort"
 )
 
@@ -28,26 +29,188 @@ type Interface[E any] sort.Interface[E]
 // but otherwise it is worse than a simple quick sort. So it fallbacks to
 // quicksort if the unsorted part is too big.
 //
+// If none of the appended elements is less than the last element of
+// the sorted prefix (a common case of appending in roughly sorted order),
+// then only the tail is sorted: O(k*ln(k)).
+//
+// Any slice length is supported (up to `math.MaxInt`, which is the limit
+// of Go itself); tailLength greater than the length of the slice causes
+// a panic.
+//
 // Roughly:
 //
 // T: O(k*ln(n) + n + k^2) -- thus if `k` is too high then: O(k^2)
 //
 // S: O(1) [if without `s`]
 func Appended[E any, S Interface[E]](s S, tailLength uint) {
+	appendedSeq(stdInterface[E, S](s), tailLength)
+}
+
+// appendedWithStrategy is the same as Appended, but it also returns
+// the label of the chosen strategy (see the Strategy* constants).
+func appendedWithStrategy[E any, S Interface[E]](s S, tailLength uint) string {
+	return appendedSeq(stdInterface[E, S](s), tailLength)
+}
+
+// appendedSeq is the implementation of Appended. It is shared by all
+// the in-place variants (AppendedFunc, AppendedIndexed, AppendedKV etc),
+// which differ only in the sequence they pass. It returns the label of
+// the chosen strategy (see the Strategy* constants).
+func appendedSeq[Q sequence](q Q, tailLength uint) string {
+	strategy := startAppendedSeq(q, tailLength, appendedPolicy{})
+	finishAppendedSeq(q, tailLength, strategy, nil)
+	return strategy
+}
+
+// appendedPolicy customizes the decision of startAppendedSeq for
+// a variant of Appended. The zero value is the policy of Appended itself.
+type appendedPolicy struct {
+	// shouldUse (if not nil) replaces shouldUseAppended: it returns true
+	// if the variant should merge a tail of tailSize elements into
+	// a slice of totalSize elements instead of sorting the whole slice.
+	shouldUse func(totalSize, tailSize uint) bool
+
+	// sortedTail is true if the tail is known to be (almost) sorted, so
+	// longer tails are merged (see sortedTailDiscountDivisor).
+	sortedTail bool
+
+	// noSortTail disables StrategySortTail, for the variants which
+	// unsorted elements are not only in the tail.
+	noSortTail bool
+}
+
+// alwaysUseAppended is the shouldUse policy of the variants, which merge
+// the tail into the prefix regardless of its length (since their merge
+// does not degrade on long tails).
+func alwaysUseAppended(totalSize, tailSize uint) bool {
+	return true
+}
+
+// neverUseAppended is the shouldUse policy of the variants, which are
+// asked to always sort the whole slice.
+func neverUseAppended(totalSize, tailSize uint) bool {
+	return false
+}
+
+// startAppendedSeq is the common beginning of Appended and all its
+// variants: it checks tailLength, validates Less (if ValidateLess is
+// true), chooses the strategy according to the policy and reports it
+// to OnStrategy. Thus the same input takes the same path in all
+// the variants, which differ only in the policy and in how they perform
+// the chosen strategy (see finishAppendedSeq).
+func startAppendedSeq[Q sequence](q Q, tailLength uint, policy appendedPolicy) string {
+	length := uint(q.Len())
+	strategy := chooseAppendedStrategySeq(q, length, tailLength, policy)
+	if OnStrategy != nil {
+		OnStrategy(strategy, length, tailLength)
+	}
+	return strategy
+}
+
+// startAppended is the same as startAppendedSeq, but for an Interface.
+func startAppended[E any, S Interface[E]](s S, tailLength uint, policy appendedPolicy) string {
+	return startAppendedSeq(stdInterface[E, S](s), tailLength, policy)
+}
+
+// finishAppendedSeq performs the strategy chosen by startAppendedSeq
+// the same way as Appended. onStep (if not nil) is called after each step
+// of the group-insert, see groupInsertDescendingTailLimited.
+func finishAppendedSeq[Q sequence](q Q, tailLength uint, strategy string, onStep func(branch string, inserted uint)) {
+	switch strategy {
+	case StrategyFallbackSort:
+		q.Sort(0, q.Len())
+	case StrategySortTail:
+		length := q.Len()
+		q.Sort(length-int(tailLength), length)
+	case StrategyGroupInsert:
+		length := q.Len()
+		splitIdx := length - int(tailLength)
+		sortTailDescendingSeq(q, splitIdx, length)
+		groupInsertDescendingTailSeq(q, uint(splitIdx), math.MaxInt, false, onStep)
+	}
+}
+
+// finishAppended is the same as finishAppendedSeq, but for an Interface.
+func finishAppended[E any, S Interface[E]](s S, tailLength uint, strategy string) {
+	finishAppendedSeq(stdInterface[E, S](s), tailLength, strategy, nil)
+}
+
+// checkTailLength panics if tailLength is greater than the length of
+// the slice.
+func checkTailLength(length int, tailLength uint) {
+	if tailLength > uint(length) {
+		panic(fmt.Sprintf("tailLength (%d) cannot be greater than the lenght of the provided slice (%d)", tailLength, length))
+	}
+}
+
+// chooseAppendedStrategy returns the label of the strategy (see
+// the Strategy* constants) Appended uses for the given slice.
+func chooseAppendedStrategy[E any, S Interface[E]](s S, tailLength uint) string {
+	return chooseAppendedStrategySeq(stdInterface[E, S](s), uint(len(s)), tailLength, appendedPolicy{})
+}
+
+// chooseAppendedStrategySeq is the same as chooseAppendedStrategy, but
+// for any sequence (of the given length) and any policy.
+func chooseAppendedStrategySeq[Q sequence](q Q, length, tailLength uint, policy appendedPolicy) string {
 	if tailLength == 0 {
-		return
+		return StrategyAlreadySorted
+	}
+	checkTailLength(int(length), tailLength)
+	if ValidateLess {
+		validateLessSeq(q)
 	}
 
-	if !shouldUseAppended(uint(len(s)), tailLength) {
-		if tailLength > uint(len(s)) {
-			panic(fmt.Sprintf("tailLength (%d) cannot be greater than the lenght of the provided slice (%d)", tailLength, len(s)))
-		}
+	if isBelowMinAppendedSize(length) || tailLength == length {
+		return StrategyFallbackSort
+	}
+	if !policy.noSortTail && isTailAfterPrefixSeq(q, length-tailLength) {
+		return StrategySortTail
+	}
+	if !policy.shouldUseAppended(length, tailLength) {
+		return StrategyFallbackSort
+	}
+	return StrategyGroupInsert
+}
 
-		sort.Sort(s)
-		return
+// shouldGroupInsert returns true if chooseAppendedStrategySeq chooses
+// StrategyGroupInsert for the given sizes, unless the tail is after
+// the prefix (StrategySortTail), which depends on the data.
+func (policy appendedPolicy) shouldGroupInsert(totalSize, tailSize uint) bool {
+	return tailSize > 0 && tailSize < totalSize &&
+		!isBelowMinAppendedSize(totalSize) &&
+		policy.shouldUseAppended(totalSize, tailSize)
+}
+
+// shouldUseAppended is the same as the function shouldUseAppended, but
+// according to the policy.
+func (policy appendedPolicy) shouldUseAppended(totalSize, tailSize uint) bool {
+	if policy.sortedTail {
+		tailSize -= tailSize / sortedTailDiscountDivisor
 	}
+	if policy.shouldUse != nil {
+		return policy.shouldUse(totalSize, tailSize)
+	}
+	return shouldUseAppended(totalSize, tailSize)
+}
 
-	groupInsertAppendSort(s, tailLength)
+// sortedTailDiscountDivisor defines how much shorter a tail known to be
+// (almost) sorted is considered when deciding whether to use
+// the optimization: `k - k/sortedTailDiscountDivisor`, that is ~10%
+// shorter. Such a tail is sorted in O(k) instead of O(k*ln(k)), thus
+// the crossover with the full sort is at ~1.1 times longer tails than of
+// a random tail (measured for slices of length from 2^14 to 2^18, see
+// TestAppendedWithHintThreshold).
+const sortedTailDiscountDivisor = 10
+
+// AppendedClamped is the same as Appended, but instead of panicking
+// it treats tailLength greater than the length of the slice as "the whole
+// slice is unsorted" (and just sorts it). It is convenient if tailLength
+// is only an estimation.
+func AppendedClamped[E any, S Interface[E]](s S, tailLength uint) {
+	if tailLength > uint(len(s)) {
+		tailLength = uint(len(s))
+	}
+	Appended(s, tailLength)
 }
 
 // AppendedWithBuf is the same as Appended but:
@@ -55,34 +218,48 @@ func Appended[E any, S Interface[E]](s S, tailLength uint) {
 // * Requires a buffer.
 //
 // The buffer lenght should be exactly the same as the lenght
-// of the unsorted tail.
+// of the unsorted tail. After the call the buffer content is unspecified
+// (it may keep copies of the elements); if the elements contain pointers
+// and the buffer is reused, consider AppendedWithBufClear.
 //
 // For example if there is a slice of length 65536 with only 512 unsorted
 // elements in the end, then `Appended` on my laptop works ~30 times faster
 // than just using `Slice` on the whole slice.
 //
+// Similar to Appended, if none of the appended elements is less than
+// the last element of the sorted prefix, then only the tail is sorted.
+//
 // T: O(k*ln(n) + n)
 //
 // S: O(k) [if without `s`]
 func AppendedWithBuf[E any, S Interface[E]](s S, buf []E) {
 	tailLength := uint(len(buf))
-	if tailLength == 0 {
+	strategy := startAppended(s, tailLength, appendedPolicy{shouldUse: shouldUseAppendedWithBuf})
+	if strategy != StrategyGroupInsert {
+		finishAppended(s, tailLength, strategy)
 		return
 	}
+	groupInsertAppendSortWithBuf(s, buf)
+}
 
-	if !shouldUseAppendedWithBuf(uint(len(s)), tailLength) {
-		if tailLength > uint(len(s)) {
-			panic(fmt.Sprintf("tailLength (%d) cannot be greater than the lenght of the provided slice (%d)", tailLength, len(s)))
-		}
-
-		sort.Sort(s)
-		return
+// AppendedWithBufN is the same as AppendedWithBuf, but the length of
+// the unsorted tail is passed explicitly, and only the first tailLength
+// elements of the buffer are used. It allows to reuse a single big scratch
+// buffer without re-slicing it on each call.
+//
+// It panics if the capacity of the buffer is less than tailLength.
+func AppendedWithBufN[E any, S Interface[E]](s S, buf []E, tailLength uint) {
+	if uint(cap(buf)) < tailLength {
+		panic(fmt.Sprintf("the buffer capacity (%d) is less than tailLength (%d)", cap(buf), tailLength))
 	}
-
-	groupInsertAppendSortWithBuf(s, buf)
+	AppendedWithBuf(s, buf[:tailLength])
 }
 
 func groupInsertAppendSort[E any, S Interface[E]](s S, tailLength uint) {
+	groupInsertAppendSortSeq(stdInterface[E, S](s), tailLength)
+}
+
+func groupInsertAppendSortSeq[Q sequence](q Q, tailLength uint) {
 	// Strategy:
 	//
 	// This is basically an insert search, which:
@@ -93,50 +270,145 @@ func groupInsertAppendSort[E any, S Interface[E]](s S, tailLength uint) {
 	// it is easier to read. The difference is groupInsertAppendSortWithBuf
 	// stores the unsorted values in an external storage, which allows avoiding
 	// slice rotations, and just do the "move" (/copy) directly.
-	length := len(s)
-	if int(tailLength) > length {
-		panic(fmt.Errorf("tail is longer than the slice: %d > %d", tailLength, len(s)))
+	length := q.Len()
+	if tailLength > uint(length) {
+		panic(fmt.Errorf("tail is longer than the slice: %d > %d", tailLength, length))
 	}
 	splitIdx := uint(length) - tailLength
 	if splitIdx == 0 {
-		sort.Sort(s)
+		q.Sort(0, length)
 		return
 	}
-	rightPart := s[splitIdx:]
-	sort.Slice(rightPart, func(i, j int) bool {
-		return rightPart.Less(j, i)
-	})
+	sortTailDescendingSeq(q, int(splitIdx), length)
+	groupInsertDescendingTailSeq(q, splitIdx, math.MaxInt, false, nil)
+}
+
+// sortTailDescending sorts the tail in descending order (if it is not
+// sorted in descending order already).
+//
+// The tail is sorted in descending order because groupInsertDescendingTail
+// always inserts the first element of the unsorted block (which is adjacent
+// to the sorted prefix), and it should be the greatest one: then
+// the inserted element gets its final position right away and the rest of
+// the block is moved to the left as a whole (and stays contiguous).
+//
+// See also AppendedWithHint to override how the tail is sorted.
+func sortTailDescending[E any, S Interface[E]](tail S) {
+	sortTailDescendingSeq(stdInterface[E, S](tail), 0, len(tail))
+}
+
+// sortTailDescendingSeq is the same as sortTailDescending, but for
+// the range [a, b) of any sequence.
+func sortTailDescendingSeq[Q sequence](q Q, a, b int) {
+	if isSortedDescendingSeq(q, a, b) {
+		return
+	}
+	q.SortDescending(a, b)
+}
+
+// isSortedDescending returns true if the slice is sorted
+// in descending order.
+func isSortedDescending[E any, S Interface[E]](s S) bool {
+	return isSortedDescendingSeq(stdInterface[E, S](s), 0, len(s))
+}
+
+// isSortedDescendingSeq returns true if the range [a, b) of the sequence
+// is sorted in descending order.
+func isSortedDescendingSeq[Q sequence](q Q, a, b int) bool {
+	for idx := a + 1; idx < b; idx++ {
+		if q.Less(idx-1, idx) {
+			return false
+		}
+	}
+	return true
+}
+
+// groupInsertDescendingTail is the main part of groupInsertAppendSort:
+// it merges the tail s[splitIdx:], which is already sorted in descending
+// order, into the sorted prefix s[:splitIdx].
+func groupInsertDescendingTail[E any, S Interface[E]](s S, splitIdx uint) {
+	groupInsertDescendingTailWithBudget(s, splitIdx, math.MaxInt)
+}
 
+// groupInsertDescendingTailWithBudget is the same as
+// groupInsertDescendingTail, but it stops (and returns false) as soon as
+// the amount of moved elements exceeds movesBudget. In this case the slice
+// is left in an unspecified order (but still contains the same elements).
+func groupInsertDescendingTailWithBudget[E any, S Interface[E]](s S, splitIdx uint, movesBudget int) bool {
+	return groupInsertDescendingTailLimited(s, splitIdx, movesBudget, false, nil) == 0
+}
+
+// groupInsertDescendingTailLimited is the same as groupInsertDescendingTail,
+// but it stops as soon as the performed work exceeds budget. The work is
+// the amount of moved elements plus (if countSearches is true)
+// the comparisons of the binary searches.
+//
+// If onStep is not nil, it is called after each inserted element
+// of the tail with the label of the executed branch (see the Branch*
+// constants) and the amount of the inserted elements so far.
+//
+// It returns the length of the unfinished part: if it is not zero, then
+// s[:returned] contains the elements in an unspecified order, while
+// s[returned:] is already in its final sorted state.
+func groupInsertDescendingTailLimited[E any, S Interface[E]](s S, splitIdx uint, budget int, countSearches bool, onStep func(branch string, inserted uint)) int {
+	return groupInsertDescendingTailSeq(stdInterface[E, S](s), splitIdx, budget, countSearches, onStep)
+}
+
+// groupInsertDescendingTailSeq is the same as
+// groupInsertDescendingTailLimited, but for any sequence.
+func groupInsertDescendingTailSeq[Q sequence](q Q, splitIdx uint, budget int, countSearches bool, onStep func(branch string, inserted uint)) int {
+	length := q.Len()
+	tailLength := uint(length) - splitIdx
 	unsortedStartIdx := splitIdx
 	unsortedEnd := length
+	work := 0
 	for unsortedCount := tailLength; unsortedCount > 0; unsortedCount-- {
+		if work > budget {
+			return unsortedEnd
+		}
+		if countSearches {
+			work += bits.Len(unsortedStartIdx)
+		}
 		leftIdx := sort.Search(int(unsortedStartIdx), func(i int) bool {
-			return s.Less(int(unsortedStartIdx), i)
+			return q.Less(int(unsortedStartIdx), i)
 		})
 
+		var branch string
 		if leftIdx == int(unsortedStartIdx) {
 			if unsortedStartIdx == 0 {
-				slices.Reverse(s[0:unsortedCount])
+				q.Reverse(0, int(unsortedCount))
+				if onStep != nil {
+					onStep(BranchReverse, tailLength)
+				}
 				break
 			}
 			if leftIdx > 0 {
 				leftIdx--
 			}
-			if s.Less(int(unsortedStartIdx), int(unsortedStartIdx)-1) {
-				slices.Rotate(s[leftIdx:leftIdx+int(unsortedCount)+1], -2)
+			if q.Less(int(unsortedStartIdx), int(unsortedStartIdx)-1) {
+				q.Rotate(leftIdx, leftIdx+int(unsortedCount)+1, -2)
 				unsortedStartIdx = uint(leftIdx)
+				branch = BranchRotate2
 			} else {
-				slices.Rotate(s[leftIdx+1:leftIdx+int(unsortedCount)+1], -1)
+				q.Rotate(leftIdx+1, leftIdx+int(unsortedCount)+1, -1)
 				unsortedStartIdx = uint(leftIdx) + 1
+				branch = BranchRotate1
 			}
+			work += int(unsortedCount) + 1
 		} else {
-			slices.Rotate(s[leftIdx+1:unsortedEnd], unsortedEnd-int(unsortedStartIdx))
-			s[leftIdx], s[leftIdx+1] = s[leftIdx+1], s[leftIdx]
-			slices.Rotate(s[leftIdx:leftIdx+int(unsortedCount)+1], -2)
+			branch = BranchBigRotate
+			q.Rotate(leftIdx+1, unsortedEnd, unsortedEnd-int(unsortedStartIdx))
+			q.Swap(leftIdx, leftIdx+1)
+			q.Rotate(leftIdx, leftIdx+int(unsortedCount)+1, -2)
+			work += unsortedEnd - leftIdx + int(unsortedCount) + 2
 			unsortedStartIdx = uint(leftIdx)
 		}
 		unsortedEnd = int(unsortedStartIdx) + int(unsortedCount) - 1
+		if onStep != nil {
+			onStep(branch, tailLength-unsortedCount+1)
+		}
 	}
+	return 0
 }
 
 func groupInsertAppendSortWithBuf[E any, S Interface[E]](s S, buf []E) {
@@ -152,7 +424,7 @@ func groupInsertAppendSortWithBuf[E any, S Interface[E]](s S, buf []E) {
 	// complexity).
 	tailLength := len(buf)
 	length := len(s)
-	if int(tailLength) > length {
+	if tailLength > length {
 		panic(fmt.Errorf("tail is longer than the slice: %d > %d", tailLength, len(s)))
 	}
 	splitIdx := length - tailLength
@@ -184,6 +456,42 @@ func groupInsertAppendSortWithBuf[E any, S Interface[E]](s S, buf []E) {
 	}
 }
 
+// isTailAfterPrefix returns true if none of the elements of the tail
+// s[splitIdx:] is less than the last element of the (non-empty) sorted
+// prefix s[:splitIdx]. In this case it is enough to sort only the tail.
+//
+// T: O(k)
+func isTailAfterPrefix[E any, S Interface[E]](s S, splitIdx uint) bool {
+	return isTailAfterPrefixSeq(stdInterface[E, S](s), splitIdx)
+}
+
+// isTailAfterPrefixSeq is the same as isTailAfterPrefix, but for any
+// sequence.
+func isTailAfterPrefixSeq[Q sequence](q Q, splitIdx uint) bool {
+	length := q.Len()
+	prefixMaxIdx := int(splitIdx) - 1
+	for idx := int(splitIdx); idx < length; idx++ {
+		if q.Less(idx, prefixMaxIdx) {
+			return false
+		}
+	}
+	return true
+}
+
+// MinAppendedSize is the length of a slice, below which Appended always
+// just sorts the whole slice: for tiny slices the overhead of
+// the optimization is not worth it.
+//
+// It is not safe to modify MinAppendedSize concurrently with calls of
+// Appended; set it once on initialization.
+var MinAppendedSize = 16
+
+// isBelowMinAppendedSize returns true if Appended just sorts a slice
+// of the given length due to MinAppendedSize.
+func isBelowMinAppendedSize(totalSize uint) bool {
+	return MinAppendedSize > 0 && totalSize < uint(MinAppendedSize)
+}
+
 // shouldUseAppended returns true if Appended is a more optimal
 // sorter than Slice.
 //
@@ -204,12 +512,9 @@ func shouldUseAppended(totalSize, tailSize uint) bool {
 	// 262144: 4096
 	// 524288: 4096-8192
 	// 1048576: 8192
-	switch {
-	case totalSize < 512: // k is too small an the "k^2" is not dominating yet
-		return tailSize*4 < totalSize
-	default:
-		return tailSize*tailSize/64 < totalSize // now "k^2" is dominating
-	}
+	//
+	// See AppendedSizeClasses.
+	return shouldUseAppendedTable(totalSize, tailSize)
 }
 
 // shouldUseAppendedWithBuf returns true if AppendedWithBuf is a more optimal
@@ -223,10 +528,18 @@ func shouldUseAppendedWithBuf(totalSize, tailSize uint) bool {
 	case totalSize < 10:
 		return false
 	case totalSize < 64:
-		return tailSize*3 < totalSize
+		return mulLess(tailSize, 3, totalSize, 1)
 	case totalSize < 256:
-		return tailSize*2 < totalSize
+		return mulLess(tailSize, 2, totalSize, 1)
 	default:
-		return tailSize*5 < totalSize*3
+		return mulLess(tailSize, 5, totalSize, 3)
 	}
 }
+
+// mulLess returns true if a*b < c*d. Unlike a direct comparison of
+// the products it is not affected by overflows.
+func mulLess(a, b, c, d uint) bool {
+	hi0, lo0 := bits.Mul(a, b)
+	hi1, lo1 := bits.Mul(c, d)
+	return hi0 < hi1 || (hi0 == hi1 && lo0 < lo1)
+}
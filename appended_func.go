@@ -0,0 +1,131 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"cmp"
+)
+
+// AppendedFunc is the same as Appended, but it works with a plain slice
+// and a three-way comparison function (like `slices.SortFunc` and
+// `cmp.Compare`) instead of an Interface implementation.
+//
+// cmp(a, b) should return a negative number when a < b, a positive number
+// when a > b and zero when a == b.
+//
+// A comparison function cannot be wrapped into a value satisfying Interface
+// (which is satisfied only by slice types), thus this is a separate function,
+// but it shares the implementation with Appended (including the checks,
+// MinAppendedSize and OnStrategy). It is slightly slower than Appended due
+// to the indirect calls of cmp.
+func AppendedFunc[E any](s []E, tailLength uint, cmp func(a, b E) int) {
+	appendedLessFunc(s, tailLength, func(a, b E) bool {
+		return cmp(a, b) < 0
+	})
+}
+
+// appendedLessFunc is the same as Appended, but uses the provided less
+// function to compare the elements.
+func appendedLessFunc[E any](s []E, tailLength uint, less func(a, b E) bool) {
+	appendedSeq(funcSeq[E]{s: s, less: less}, tailLength)
+}
+
+// OrderedCmp implements Interface for a slice of an ordered type, deriving
+// Less from `cmp.Compare` (as `cmp.Compare(s[i], s[j]) < 0`), thus it
+// could be passed to Appended directly:
+//
+//	xsort.Appended(xsort.OrderedCmp[int](ints), 10)
+//
+// For other types (or other comparison functions) see AppendedFunc.
+type OrderedCmp[E cmp.Ordered] []E
+
+// Less implements Interface.
+func (s OrderedCmp[E]) Less(i, j int) bool {
+	return cmp.Compare(s[i], s[j]) < 0
+}
+
+// AppendedClone returns a sorted copy of the slice, which is sorted by
+// the appended optimization (see Appended) using the provided less function.
+// The original slice is not modified.
+//
+// T: the same as Appended
+//
+// S: O(n)
+func AppendedClone[E any](s []E, tailLength uint, less func(a, b E) bool) []E {
+	checkTailLength(len(s), tailLength)
+	c := make([]E, len(s))
+	copy(c, s)
+	appendedLessFunc(c, tailLength, less)
+	return c
+}
+
+// SortedCopyFunc returns a sorted copy of the slice using a three-way
+// comparison function (see AppendedFunc). The original slice is not
+// modified.
+//
+// The length of the sorted prefix of s is detected by a forward scan,
+// and the rest is treated as the unsorted tail (see Appended), thus it is
+// cheap for append-heavy snapshots. Otherwise the copy is just sorted.
+//
+// T: O(n) [if only a short tail is unsorted], otherwise O(n*ln(n))
+//
+// S: O(n)
+func SortedCopyFunc[E any](s []E, cmp func(a, b E) int) []E {
+	c := make([]E, len(s))
+	copy(c, s)
+	prefixLength := 1
+	for prefixLength < len(c) && cmp(c[prefixLength], c[prefixLength-1]) >= 0 {
+		prefixLength++
+	}
+	if prefixLength >= len(c) {
+		return c
+	}
+	appendedLessFunc(c, uint(len(c)-prefixLength), func(a, b E) bool {
+		return cmp(a, b) < 0
+	})
+	return c
+}
+
+// AppendedPtr is the same as AppendedFunc, but for slices of pointers
+// which may contain nils (and with a less function instead of a three-way
+// comparison function): the nil entries are placed
+// at the front (if nilsFirst is true) or at the back of the slice, and
+// less is called only for pairs of non-nil pointers.
+//
+// The prefix is expected to be sorted according to the same policy.
+func AppendedPtr[T any](s []*T, tailLength uint, less func(a, b *T) bool, nilsFirst bool) {
+	appendedLessFunc(s, tailLength, func(a, b *T) bool {
+		switch {
+		case a == nil && b == nil:
+			return false
+		case a == nil:
+			return nilsFirst
+		case b == nil:
+			return !nilsFirst
+		default:
+			return less(a, b)
+		}
+	})
+}
+
+// AppendedBy2 is the same as AppendedFunc, but the elements are compared
+// by key1, and the ties are broken by key2 (for example to sort records
+// by category and then by timestamp).
+func AppendedBy2[E any, K1, K2 cmp.Ordered](s []E, tailLength uint, key1 func(E) K1, key2 func(E) K2) {
+	appendedLessFunc(s, tailLength, func(a, b E) bool {
+		if c := cmp.Compare(key1(a), key1(b)); c != 0 {
+			return c < 0
+		}
+		return cmp.Less(key2(a), key2(b))
+	})
+}
+
+// AppendedAny is the same as Appended, but for slices of `any` (for
+// example for heterogeneous or reflection-based data) and with a less
+// function. It is a named specialization at `E = any`, which saves
+// a generic type definition and the type inference friction.
+func AppendedAny(s []any, tailLength uint, less func(a, b any) bool) {
+	appendedLessFunc(s, tailLength, less)
+}
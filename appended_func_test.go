@@ -0,0 +1,372 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+func testAppendedFunc(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		AppendedFunc(s, tailLenght, cmp.Compare[int])
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedFunc(t *testing.T) {
+	testAppendedFunc(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedFunc(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedFunc(t, []byte{49, 255, 127}, 2)
+	testAppendedFunc(t, []byte{65, 76, 173, 37, 67, 145}, 5)
+	testAppendedFunc(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 14, 12, 8, 1}, 4)
+}
+
+func FuzzAppendedFunc(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedFunc(t, initial, tailLenght)
+	})
+}
+
+func TestAppendedFuncStruct(t *testing.T) {
+	type person struct {
+		Name string
+		Age  int
+	}
+	comparePersons := func(a, b person) int {
+		if c := cmp.Compare(a.Age, b.Age); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	}
+
+	s := []person{
+		{"Alice", 20}, {"Bob", 25}, {"Carol", 25}, {"Dave", 30}, {"Eve", 31},
+		{"Frank", 22}, {"Alan", 25}, {"Zoe", 18},
+	}
+	AppendedFunc(s, 3, comparePersons)
+
+	expected := []person{
+		{"Zoe", 18}, {"Alice", 20}, {"Frank", 22}, {"Alan", 25}, {"Bob", 25},
+		{"Carol", 25}, {"Dave", 30}, {"Eve", 31},
+	}
+	for idx := range expected {
+		if s[idx] != expected[idx] {
+			t.Fatalf("%v != %v", s, expected)
+		}
+	}
+}
+
+func TestOrderedCmp(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	for _, tailLength := range []uint{0, 1, 10, 100, 1000} {
+		s := make([]int, 1000)
+		for idx := range s {
+			s[idx] = rng.Intn(100)
+		}
+		stdsort.Ints(s[:uint(len(s))-tailLength])
+		Appended(OrderedCmp[int](s), tailLength)
+		if !stdsort.IntsAreSorted(s) {
+			t.Fatalf("tailLength %d: not sorted: %v", tailLength, s)
+		}
+	}
+
+	if !(OrderedCmp[string]{"a", "b"}).Less(0, 1) || (OrderedCmp[string]{"a", "a"}).Less(0, 1) {
+		t.Fatal("unexpected Less")
+	}
+}
+
+func TestAppendedClone(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	s := make([]int, 1000)
+	for idx := range s {
+		s[idx] = rng.Intn(1000)
+	}
+	stdsort.Ints(s[:990])
+	original := make([]int, len(s))
+	copy(original, s)
+
+	c := AppendedClone(s, 10, func(a, b int) bool {
+		return a < b
+	})
+	if !stdsort.IntsAreSorted(c) {
+		t.Fatalf("not sorted: %v", c)
+	}
+	if !intsEqual(original, s) {
+		t.Fatalf("the original slice was modified: %v != %v", original, s)
+	}
+	if &c[0] == &s[0] {
+		t.Fatalf("the result shares the memory with the original slice")
+	}
+}
+
+func testSortedCopyFunc(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	original := make([]int, len(s))
+	copy(original, s)
+	t.Run(testName, func(t *testing.T) {
+		c := SortedCopyFunc(s, cmp.Compare[int])
+		if !intsEqual(original, s) {
+			t.Fatalf("the original slice was modified: %v != %v", original, s)
+		}
+		expected := append([]int{}, original...)
+		stdsort.Ints(expected)
+		if !intsEqual(expected, c) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", expected, c, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+		if len(c) > 0 && &c[0] == &s[0] {
+			t.Fatalf("the result shares the memory with the original slice")
+		}
+	})
+}
+
+func TestSortedCopyFunc(t *testing.T) {
+	testSortedCopyFunc(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testSortedCopyFunc(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testSortedCopyFunc(t, []byte{5, 4, 3, 2, 1}, 5)
+	testSortedCopyFunc(t, []byte{1, 2, 3}, 0)
+	testSortedCopyFunc(t, nil, 0)
+
+	t.Run("appended", func(t *testing.T) {
+		s := make([]int, 1000)
+		for idx := range s {
+			s[idx] = idx * 2
+		}
+		s[len(s)-1] = 1
+		var calls int
+		c := SortedCopyFunc(s, func(a, b int) int {
+			calls++
+			return cmp.Compare(a, b)
+		})
+		if !stdsort.IntsAreSorted(c) || c[1] != 1 {
+			t.Fatalf("unexpected result: %v", c[:10])
+		}
+		// the prefix scan and a binary search
+		if calls > len(s)+64 {
+			t.Fatalf("too many comparisons: %d", calls)
+		}
+	})
+}
+
+func FuzzSortedCopyFunc(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testSortedCopyFunc(t, initial, tailLenght)
+	})
+}
+
+func testAppendedPtr(t *testing.T, initial []byte, tailLenght uint, nilsFirst bool) {
+	// zeros become nils
+	toPtrs := func(in []byte) []*int {
+		out := make([]*int, len(in))
+		for idx, v := range in {
+			if v != 0 {
+				v := int(v)
+				out[idx] = &v
+			}
+		}
+		return out
+	}
+	less := func(a, b *int) bool {
+		if a == nil || b == nil {
+			t.Fatal("less is called for a nil pointer")
+		}
+		return *a < *b
+	}
+
+	initial = append([]byte{}, initial...)
+	splitIdx := len(initial) - int(tailLenght)
+	prefix := initial[:splitIdx]
+	stdsort.Slice(prefix, func(i, j int) bool {
+		return prefix[i] < prefix[j]
+	})
+	if !nilsFirst {
+		// move zeros to the end of the prefix
+		nils := 0
+		for nils < len(prefix) && prefix[nils] == 0 {
+			nils++
+		}
+		copy(prefix, prefix[nils:])
+		for idx := len(prefix) - nils; idx < len(prefix); idx++ {
+			prefix[idx] = 0
+		}
+	}
+	s := toPtrs(initial)
+	t.Run(fmt.Sprintf("%v (tailLength: %d, nilsFirst: %t)", initial, tailLenght, nilsFirst), func(t *testing.T) {
+		AppendedPtr(s, tailLenght, less, nilsFirst)
+
+		var nils int
+		var values []int
+		for _, v := range initial {
+			if v == 0 {
+				nils++
+				continue
+			}
+			values = append(values, int(v))
+		}
+		stdsort.Ints(values)
+
+		if !nilsFirst {
+			s = append(s[len(s)-nils:], s[:len(s)-nils]...)
+		}
+		for idx, v := range s {
+			if idx < nils {
+				if v != nil {
+					t.Fatalf("expected nil at %d", idx)
+				}
+				continue
+			}
+			if v == nil || *v != values[idx-nils] {
+				t.Fatalf("unexpected value at %d; expected: %v", idx, values)
+			}
+		}
+	})
+}
+
+func TestAppendedPtr(t *testing.T) {
+	for _, nilsFirst := range []bool{true, false} {
+		testAppendedPtr(t, []byte{0, 1, 3, 5, 7, 11, 13, 12, 0, 4, 8}, 4, nilsFirst)
+		testAppendedPtr(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 0, 8, 14}, 4, nilsFirst)
+		testAppendedPtr(t, []byte{1, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 0, 0, 0, 0}, 4, nilsFirst)
+		testAppendedPtr(t, []byte{0, 0, 0, 0}, 2, nilsFirst)
+	}
+}
+
+func FuzzAppendedPtr(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedPtr(t, initial, tailLenght, rand.Intn(2) == 0)
+	})
+}
+
+func TestAppendedBy2(t *testing.T) {
+	type record struct {
+		Category  string
+		Timestamp int
+	}
+	var key1Calls int
+	category := func(r record) string {
+		key1Calls++
+		return r.Category
+	}
+	timestamp := func(r record) int {
+		return r.Timestamp
+	}
+
+	t.Run("ties", func(t *testing.T) {
+		s := []record{
+			{"a", 1}, {"a", 5}, {"b", 2}, {"b", 3}, {"c", 0},
+			{"b", 1}, {"a", 3}, {"c", -1},
+		}
+		AppendedBy2(s, 3, category, timestamp)
+		expected := []record{
+			{"a", 1}, {"a", 3}, {"a", 5}, {"b", 1}, {"b", 2}, {"b", 3}, {"c", -1}, {"c", 0},
+		}
+		for idx := range expected {
+			if s[idx] != expected[idx] {
+				t.Fatalf("%v != %v", s, expected)
+			}
+		}
+	})
+
+	t.Run("optimization_applies", func(t *testing.T) {
+		s := make([]record, 1000)
+		for idx := range s {
+			s[idx] = record{Category: string(rune('a' + idx*26/len(s))), Timestamp: idx}
+		}
+		s[len(s)-2] = record{"c", 5}
+		s[len(s)-1] = record{"a", 7}
+		key1Calls = 0
+		AppendedBy2(s, 2, category, timestamp)
+		for idx := 1; idx < len(s); idx++ {
+			prev, cur := s[idx-1], s[idx]
+			if prev.Category > cur.Category || (prev.Category == cur.Category && prev.Timestamp > cur.Timestamp) {
+				t.Fatalf("not sorted at %d: %v > %v", idx, prev, cur)
+			}
+		}
+		// a full sort would require at least n comparisons
+		if key1Calls >= len(s) {
+			t.Fatalf("too many comparisons: %d", key1Calls/2)
+		}
+	})
+}
+
+// boxedIntLess compares boxed integers of different types.
+func boxedIntLess(a, b any) bool {
+	toInt64 := func(v any) int64 {
+		switch v := v.(type) {
+		case int:
+			return int64(v)
+		case int32:
+			return int64(v)
+		case uint16:
+			return int64(v)
+		case int64:
+			return v
+		default:
+			panic(fmt.Sprintf("unexpected type %T", v))
+		}
+	}
+	return toInt64(a) < toInt64(b)
+}
+
+func testAppendedAny(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	boxed := make([]any, len(s))
+	for idx, v := range s {
+		switch idx % 4 {
+		case 0:
+			boxed[idx] = v
+		case 1:
+			boxed[idx] = int32(v)
+		case 2:
+			boxed[idx] = uint16(v)
+		default:
+			boxed[idx] = int64(v)
+		}
+	}
+	t.Run(testName, func(t *testing.T) {
+		AppendedAny(boxed, tailLenght, boxedIntLess)
+		for idx := 1; idx < len(boxed); idx++ {
+			if boxedIntLess(boxed[idx], boxed[idx-1]) {
+				t.Fatalf("not sorted at %d: %v; testCase < %s , %s >", idx, boxed, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+			}
+		}
+	})
+}
+
+func TestAppendedAny(t *testing.T) {
+	testAppendedAny(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedAny(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+
+	s := []any{1, int32(2), uint16(3), int64(4), 10, int32(5), uint16(0)}
+	AppendedAny(s, 3, boxedIntLess)
+	expected := []any{uint16(0), 1, int32(2), uint16(3), int64(4), int32(5), 10}
+	for idx := range expected {
+		if s[idx] != expected[idx] {
+			t.Fatalf("%v != %v", s, expected)
+		}
+	}
+}
+
+func FuzzAppendedAny(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedAny(t, initial, tailLenght)
+	})
+}
@@ -0,0 +1,48 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+// AppendedHeap is the same as Appended, but all the sorting (of the tail
+// and the fallback full sort) is done by a heapsort instead of
+// a quicksort. It is slower on average, but the time does not depend on
+// the order of the elements (there are no adversarial inputs) and no
+// recursion is used.
+//
+// T: O(k*ln(n) + n + k^2) -- thus if `k` is too high then: O(n*ln(n))
+// (for any input).
+//
+// S: O(1) [if without `s`]
+func AppendedHeap[E any, S Interface[E]](s S, tailLength uint) {
+	appendedSeq(heapSeq[E, S]{stdInterface[E, S](s)}, tailLength)
+}
+
+// heapSeq is the same sequence as stdInterface, but the ranges are sorted
+// by heapSort.
+type heapSeq[E any, S Interface[E]] struct {
+	stdInterface[E, S]
+}
+
+func (q heapSeq[E, S]) Sort(a, b int) {
+	heapSort(S(q.stdInterface[a:b]))
+}
+
+func (q heapSeq[E, S]) SortDescending(a, b int) {
+	heapSort(descending[E, S](q.stdInterface[a:b]))
+}
+
+// heapSort sorts the slice using the heapsort.
+//
+// T: O(n*ln(n))
+//
+// S: O(1)
+func heapSort[E any, S Interface[E]](s S) {
+	for root := len(s)/2 - 1; root >= 0; root-- {
+		siftDownMax(s, root, len(s))
+	}
+	for end := len(s) - 1; end > 0; end-- {
+		s[0], s[end] = s[end], s[0]
+		siftDownMax(s, 0, end)
+	}
+}
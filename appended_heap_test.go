@@ -0,0 +1,96 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+func testAppendedHeap(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		AppendedHeap(intSlice(s), tailLenght)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedHeap(t *testing.T) {
+	testAppendedHeap(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedHeap(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedHeap(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 14, 12, 8, 1}, 4)
+	testAppendedHeap(t, []byte{49, 255, 127}, 2)
+	testAppendedHeap(t, []byte{65, 76, 173, 37, 67, 145}, 5)
+
+	rng := rand.New(rand.NewSource(0))
+	for _, tailLength := range []uint{1, 100, 1000, 10000} {
+		s := make([]int, 10000)
+		for idx := range s {
+			s[idx] = rng.Intn(1000)
+		}
+		stdsort.Ints(s[:uint(len(s))-tailLength])
+		AppendedHeap(intSlice(s), tailLength)
+		if !stdsort.IntsAreSorted(s) {
+			t.Fatalf("tailLength %d: not sorted", tailLength)
+		}
+	}
+}
+
+func FuzzAppendedHeap(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedHeap(t, initial, tailLenght)
+	})
+}
+
+func BenchmarkAppendedHeap(b *testing.B) {
+	const (
+		totalSize = 65536
+		csCount   = 20
+	)
+	for _, tailSize := range []int{16, 512, 4096} {
+		rng := rand.New(rand.NewSource(0))
+		in := make([][]int, csCount)
+		for idx := range in {
+			in[idx] = make([]int, totalSize)
+			for i := range in[idx] {
+				in[idx][i] = rng.Intn(totalSize)
+			}
+			stdsort.Ints(in[idx][:totalSize-tailSize])
+		}
+		cs := make([][]int, csCount)
+		for idx := range cs {
+			cs[idx] = make([]int, totalSize)
+		}
+
+		for name, fn := range map[string]func(s intSlice, tailLength uint){
+			"Appended":     Appended[int, intSlice],
+			"AppendedHeap": AppendedHeap[int, intSlice],
+		} {
+			b.Run(fmt.Sprintf("tail-%d/%s", tailSize, name), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range cs {
+							copy(cs[idx], in[idx])
+						}
+						b.StartTimer()
+					}
+					fn(cs[idx], uint(tailSize))
+				}
+			})
+		}
+	}
+}
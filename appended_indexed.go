@@ -0,0 +1,70 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	stdsort "sort"
+)
+
+// AppendedIndexed is the same as Appended, but works with any
+// implementation of the standard `sort.Interface`, accessing the elements
+// only through Len, Less and Swap. Thus it also works with non-contiguous
+// storages (like chunked arrays or ropes).
+//
+// It is the same algorithm as Appended (including the checks and OnStrategy),
+// but it is slower, because every move of an element is a call of Swap.
+//
+// T: O(k*ln(n) + n + k^2) -- thus if `k` is too high then: O(k^2)
+//
+// S: O(1) [if without `s`]
+func AppendedIndexed(s stdsort.Interface, tailLength uint) {
+	appendedSeq(indexedSeq{s}, tailLength)
+}
+
+// subInterface is a view to the range [offset, offset+length) of
+// a sort.Interface.
+type subInterface struct {
+	stdsort.Interface
+	offset int
+	length int
+}
+
+func (s subInterface) Len() int {
+	return s.length
+}
+
+func (s subInterface) Less(i, j int) bool {
+	return s.Interface.Less(s.offset+i, s.offset+j)
+}
+
+func (s subInterface) Swap(i, j int) {
+	s.Interface.Swap(s.offset+i, s.offset+j)
+}
+
+// reverseIndexed reverses the range [a, b) of s.
+func reverseIndexed(s stdsort.Interface, a, b int) {
+	for i, j := a, b-1; i < j; i, j = i+1, j-1 {
+		s.Swap(i, j)
+	}
+}
+
+// rotateIndexed is the same as `slices.Rotate(s[a:b], shift)`, but
+// works through Swap.
+func rotateIndexed(s stdsort.Interface, a, b, shift int) {
+	length := b - a
+	if length == 0 {
+		return
+	}
+	shift %= length
+	if shift < 0 {
+		shift += length
+	}
+	if shift == 0 {
+		return
+	}
+	reverseIndexed(s, a, b)
+	reverseIndexed(s, a, a+shift)
+	reverseIndexed(s, a+shift, b)
+}
@@ -0,0 +1,109 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+// chunkedInts is a non-contiguous storage of integers.
+type chunkedInts struct {
+	chunks    [][]int
+	chunkSize int
+	length    int
+}
+
+func newChunkedInts(s []int, chunkSize int) *chunkedInts {
+	c := &chunkedInts{chunkSize: chunkSize, length: len(s)}
+	for len(s) > 0 {
+		n := chunkSize
+		if n > len(s) {
+			n = len(s)
+		}
+		chunk := make([]int, n)
+		copy(chunk, s[:n])
+		c.chunks = append(c.chunks, chunk)
+		s = s[n:]
+	}
+	return c
+}
+
+func (c *chunkedInts) at(idx int) *int {
+	return &c.chunks[idx/c.chunkSize][idx%c.chunkSize]
+}
+
+func (c *chunkedInts) Len() int {
+	return c.length
+}
+
+func (c *chunkedInts) Less(i, j int) bool {
+	return *c.at(i) < *c.at(j)
+}
+
+func (c *chunkedInts) Swap(i, j int) {
+	a, b := c.at(i), c.at(j)
+	*a, *b = *b, *a
+}
+
+func (c *chunkedInts) slice() []int {
+	var s []int
+	for _, chunk := range c.chunks {
+		s = append(s, chunk...)
+	}
+	return s
+}
+
+func testAppendedIndexed(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		chunked := newChunkedInts(s, 3)
+		AppendedIndexed(chunked, tailLenght)
+		stdsort.Ints(c)
+		if result := chunked.slice(); !intsEqual(c, result) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, result, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedIndexed(t *testing.T) {
+	testAppendedIndexed(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedIndexed(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedIndexed(t, []byte{49, 255, 127}, 2)
+	testAppendedIndexed(t, []byte{65, 76, 173, 37, 67, 145}, 5)
+	testAppendedIndexed(t, []byte{20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 3, 25, 40, 1}, 4)
+}
+
+func FuzzAppendedIndexed(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedIndexed(t, initial, tailLenght)
+	})
+}
+
+func testGroupInsertAppendSortIndexed(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		chunked := newChunkedInts(s, 3)
+		groupInsertAppendSortSeq(indexedSeq{chunked}, tailLenght)
+		stdsort.Ints(c)
+		if result := chunked.slice(); !intsEqual(c, result) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, result, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func FuzzGroupInsertAppendSortIndexed(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testGroupInsertAppendSortIndexed(t, initial, tailLenght)
+	})
+}
@@ -0,0 +1,167 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"cmp"
+
+	"github.com/go-ng/slices"
+	"github.com/go-ng/sort"
+)
+
+// AppendedOrdered is the same as Appended, but for a plain slice of
+// an ordered type (sorted in ascending order): it does not require
+// to implement Interface, for example:
+//
+//	xsort.AppendedOrdered(ints, 10)
+//
+// NaN values are ordered before any other values (like `cmp.Less`).
+func AppendedOrdered[E cmp.Ordered](s []E, tailLength uint) {
+	Appended(OrderedAsc[E](s), tailLength)
+}
+
+// AppendedInt is the same as Appended, but for []int (it does not
+// require to implement Interface). It is not generic: the shared
+// implementation is instantiated only once (for a concrete sequence of ints
+// comparing the elements directly), so it avoids the indirection of
+// Interface, and using it instead of generic functions for different
+// types keeps the code size small (for example for WASM).
+func AppendedInt(s []int, tailLength uint) {
+	appendedSeq(intsSeq(s), tailLength)
+}
+
+// AppendedFloat64 is the same as AppendedInt, but for []float64.
+//
+// NaN values are ordered before any other values (like `sort.Float64s`).
+func AppendedFloat64(s []float64, tailLength uint) {
+	appendedSeq(float64sSeq(s), tailLength)
+}
+
+// AppendedString is the same as AppendedInt, but for []string.
+func AppendedString(s []string, tailLength uint) {
+	appendedSeq(stringsSeq(s), tailLength)
+}
+
+// AppendedIntWithBuf is the same as AppendedWithBuf, but for []int (see
+// AppendedInt).
+func AppendedIntWithBuf(s []int, buf []int) {
+	AppendedWithBuf(intsSeq(s), buf)
+}
+
+// AppendedFloat64WithBuf is the same as AppendedWithBuf, but for []float64
+// (see AppendedFloat64).
+func AppendedFloat64WithBuf(s []float64, buf []float64) {
+	AppendedWithBuf(float64sSeq(s), buf)
+}
+
+// AppendedStringWithBuf is the same as AppendedWithBuf, but for []string
+// (see AppendedString).
+func AppendedStringWithBuf(s []string, buf []string) {
+	AppendedWithBuf(stringsSeq(s), buf)
+}
+
+// intsSeq is a sequence (and an Interface) of ints in ascending order.
+type intsSeq []int
+
+func (s intsSeq) Len() int {
+	return len(s)
+}
+
+func (s intsSeq) Less(i, j int) bool {
+	return s[i] < s[j]
+}
+
+func (s intsSeq) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+func (s intsSeq) Rotate(a, b, shift int) {
+	slices.Rotate(s[a:b], shift)
+}
+
+func (s intsSeq) Reverse(a, b int) {
+	slices.Reverse(s[a:b])
+}
+
+func (s intsSeq) Sort(a, b int) {
+	sort.Sort(s[a:b])
+}
+
+func (s intsSeq) SortDescending(a, b int) {
+	// equal ints are indistinguishable, so reversing an ascending order
+	// is enough
+	sort.Sort(s[a:b])
+	slices.Reverse(s[a:b])
+}
+
+// float64sSeq is a sequence (and an Interface) of float64 values in
+// ascending order (NaN values go first).
+type float64sSeq []float64
+
+func (s float64sSeq) Len() int {
+	return len(s)
+}
+
+func (s float64sSeq) Less(i, j int) bool {
+	return s[i] < s[j] || (s[i] != s[i] && s[j] == s[j])
+}
+
+func (s float64sSeq) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+func (s float64sSeq) Rotate(a, b, shift int) {
+	slices.Rotate(s[a:b], shift)
+}
+
+func (s float64sSeq) Reverse(a, b int) {
+	slices.Reverse(s[a:b])
+}
+
+func (s float64sSeq) Sort(a, b int) {
+	sort.Sort(s[a:b])
+}
+
+func (s float64sSeq) SortDescending(a, b int) {
+	// the order of equal values (like -0 and +0, or NaNs) is unspecified
+	// anyway, so reversing an ascending order is enough
+	sort.Sort(s[a:b])
+	slices.Reverse(s[a:b])
+}
+
+// stringsSeq is a sequence (and an Interface) of strings in ascending
+// order.
+type stringsSeq []string
+
+func (s stringsSeq) Len() int {
+	return len(s)
+}
+
+func (s stringsSeq) Less(i, j int) bool {
+	return s[i] < s[j]
+}
+
+func (s stringsSeq) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+func (s stringsSeq) Rotate(a, b, shift int) {
+	slices.Rotate(s[a:b], shift)
+}
+
+func (s stringsSeq) Reverse(a, b int) {
+	slices.Reverse(s[a:b])
+}
+
+func (s stringsSeq) Sort(a, b int) {
+	sort.Sort(s[a:b])
+}
+
+func (s stringsSeq) SortDescending(a, b int) {
+	// equal strings are indistinguishable, so reversing an ascending order
+	// is enough
+	sort.Sort(s[a:b])
+	slices.Reverse(s[a:b])
+}
@@ -0,0 +1,290 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+func testAppendedInt(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		AppendedInt(s, tailLenght)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedInt(t *testing.T) {
+	testAppendedInt(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedInt(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedInt(t, []byte{49, 255, 127}, 2)
+	testAppendedInt(t, []byte{65, 76, 173, 37, 67, 145}, 5)
+}
+
+func FuzzAppendedInt(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedInt(t, initial, tailLenght)
+	})
+}
+
+func testAppendedOrdered(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		AppendedOrdered(s, tailLenght)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedOrdered(t *testing.T) {
+	testAppendedOrdered(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedOrdered(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedOrdered(t, []byte{49, 255, 127}, 2)
+
+	s := []float64{-1, 0, 0.5, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, math.NaN(), 1.5, math.Inf(-1)}
+	AppendedOrdered(s, 3)
+	if !math.IsNaN(s[0]) {
+		t.Fatalf("NaN is expected to be the first: %v", s)
+	}
+	if !stdsort.Float64sAreSorted(s[1:]) {
+		t.Fatalf("not sorted: %v", s)
+	}
+}
+
+func FuzzAppendedOrdered(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedOrdered(t, initial, tailLenght)
+	})
+}
+
+func TestAppendedFloat64(t *testing.T) {
+	s := []float64{-1, 0, 0.5, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, math.NaN(), 1.5, math.Inf(-1)}
+	AppendedFloat64(s, 3)
+	if !math.IsNaN(s[0]) {
+		t.Fatalf("NaN is expected to be the first: %v", s)
+	}
+	if !stdsort.Float64sAreSorted(s[1:]) {
+		t.Fatalf("not sorted: %v", s)
+	}
+}
+
+func TestAppendedString(t *testing.T) {
+	s := []string{"a", "c", "e", "g", "i", "k", "m", "o", "q", "s", "u", "w", "y", "z", "b"}
+	AppendedString(s, 1)
+	if !stdsort.StringsAreSorted(s) {
+		t.Fatalf("not sorted: %v", s)
+	}
+}
+
+func testAppendedOrderedWithBuf(t *testing.T, initial []byte, tailLenght uint) {
+	s, _, _, testName := prepareTestCase(initial, tailLenght)
+	t.Run(testName, func(t *testing.T) {
+		// int
+		expected := make([]int, len(s))
+		copy(expected, s)
+		AppendedWithBuf(intSlice(expected), make([]int, tailLenght))
+		AppendedIntWithBuf(s, make([]int, tailLenght))
+		if !intsEqual(expected, s) {
+			t.Fatalf("AppendedIntWithBuf: %v != %v", s, expected)
+		}
+
+		// string
+		strs := make([]string, len(initial))
+		for idx, v := range initial {
+			strs[idx] = fmt.Sprintf("%03d", v)
+		}
+		expectedStrs := make([]string, len(strs))
+		copy(expectedStrs, strs)
+		AppendedWithBuf(stdsort.StringSlice(expectedStrs), make([]string, tailLenght))
+		AppendedStringWithBuf(strs, make([]string, tailLenght))
+		if strings.Join(expectedStrs, ",") != strings.Join(strs, ",") {
+			t.Fatalf("AppendedStringWithBuf: %v != %v", strs, expectedStrs)
+		}
+	})
+}
+
+func TestAppendedOrderedWithBuf(t *testing.T) {
+	testAppendedOrderedWithBuf(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedOrderedWithBuf(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedOrderedWithBuf(t, []byte{49, 255, 127}, 2)
+	testAppendedOrderedWithBuf(t, []byte{65, 76, 173, 37, 67, 145}, 5)
+
+	s := []float64{-1, 0, 0.5, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, math.NaN(), 1.5, math.Inf(-1)}
+	AppendedFloat64WithBuf(s, make([]float64, 3))
+	if !math.IsNaN(s[0]) {
+		t.Fatalf("NaN is expected to be the first: %v", s)
+	}
+	if !stdsort.Float64sAreSorted(s[1:]) {
+		t.Fatalf("not sorted: %v", s)
+	}
+}
+
+func FuzzAppendedOrderedWithBuf(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedOrderedWithBuf(t, initial, tailLenght)
+	})
+}
+
+// testAppendedSpecialized checks that the non-generic functions produce
+// the same results as the generic ones.
+func testAppendedSpecialized(t *testing.T, initial []byte, tailLenght uint) {
+	s, _, _, testName := prepareTestCase(initial, tailLenght)
+	t.Run(testName, func(t *testing.T) {
+		strs := make([]string, len(s))
+		for idx, v := range s {
+			strs[idx] = fmt.Sprintf("%03d", v)
+		}
+
+		expected := append([]int{}, s...)
+		Appended(OrderedAsc[int](expected), tailLenght)
+		ints := append([]int{}, s...)
+		AppendedInt(ints, tailLenght)
+		if !intsEqual(expected, ints) {
+			t.Fatalf("AppendedInt: %v != %v", ints, expected)
+		}
+		ints = append(ints[:0], s...)
+		AppendedIntWithBuf(ints, make([]int, tailLenght))
+		if !intsEqual(expected, ints) {
+			t.Fatalf("AppendedIntWithBuf: %v != %v", ints, expected)
+		}
+
+		floats := make([]float64, len(s))
+		for idx, v := range s {
+			floats[idx] = float64(v) - 100
+			if v == 0 {
+				floats[idx] = math.NaN()
+			}
+		}
+		expectedFloats := append([]float64{}, floats...)
+		Appended(OrderedAsc[float64](expectedFloats), tailLenght)
+		result64 := append([]float64{}, floats...)
+		AppendedFloat64(result64, tailLenght)
+		if fmt.Sprint(expectedFloats) != fmt.Sprint(result64) {
+			t.Fatalf("AppendedFloat64: %v != %v", result64, expectedFloats)
+		}
+		result64 = append(result64[:0], floats...)
+		AppendedFloat64WithBuf(result64, make([]float64, tailLenght))
+		if fmt.Sprint(expectedFloats) != fmt.Sprint(result64) {
+			t.Fatalf("AppendedFloat64WithBuf: %v != %v", result64, expectedFloats)
+		}
+
+		expectedStrs := append([]string{}, strs...)
+		Appended(OrderedAsc[string](expectedStrs), tailLenght)
+		result := append([]string{}, strs...)
+		AppendedString(result, tailLenght)
+		if strings.Join(expectedStrs, ",") != strings.Join(result, ",") {
+			t.Fatalf("AppendedString: %v != %v", result, expectedStrs)
+		}
+		result = append(result[:0], strs...)
+		AppendedStringWithBuf(result, make([]string, tailLenght))
+		if strings.Join(expectedStrs, ",") != strings.Join(result, ",") {
+			t.Fatalf("AppendedStringWithBuf: %v != %v", result, expectedStrs)
+		}
+	})
+}
+
+func TestAppendedSpecialized(t *testing.T) {
+	testAppendedSpecialized(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedSpecialized(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedSpecialized(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 14, 12, 8, 1}, 4)
+	testAppendedSpecialized(t, []byte{49, 255, 127}, 2)
+	testAppendedSpecialized(t, []byte{65, 76, 173, 37, 67, 145}, 5)
+}
+
+func FuzzAppendedSpecialized(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedSpecialized(t, initial, tailLenght)
+	})
+}
+
+func BenchmarkAppendedOrdered(b *testing.B) {
+	const (
+		totalSize = 65536
+		tailSize  = 512
+		csCount   = 20
+	)
+
+	rng := rand.New(rand.NewSource(0))
+	inInts := make([][]int, csCount)
+	inFloats := make([][]float64, csCount)
+	inStrings := make([][]string, csCount)
+	for idx := range inInts {
+		inInts[idx] = make([]int, totalSize)
+		inFloats[idx] = make([]float64, totalSize)
+		inStrings[idx] = make([]string, totalSize)
+		for i := 0; i < totalSize; i++ {
+			v := rng.Intn(totalSize)
+			inInts[idx][i] = v
+			inFloats[idx][i] = float64(v)
+			inStrings[idx][i] = fmt.Sprintf("%08d", v)
+		}
+		stdsort.Ints(inInts[idx][:totalSize-tailSize])
+		stdsort.Float64s(inFloats[idx][:totalSize-tailSize])
+		stdsort.Strings(inStrings[idx][:totalSize-tailSize])
+	}
+
+	ints := make([][]int, csCount)
+	floats := make([][]float64, csCount)
+	strs := make([][]string, csCount)
+	for idx := range ints {
+		ints[idx] = make([]int, totalSize)
+		floats[idx] = make([]float64, totalSize)
+		strs[idx] = make([]string, totalSize)
+	}
+	reset := func(b *testing.B) {
+		b.StopTimer()
+		for idx := range ints {
+			copy(ints[idx], inInts[idx])
+			copy(floats[idx], inFloats[idx])
+			copy(strs[idx], inStrings[idx])
+		}
+		b.StartTimer()
+	}
+
+	for _, bc := range []struct {
+		name string
+		fn   func(idx int)
+	}{
+		{"int/Appended", func(idx int) { Appended(intSlice(ints[idx]), tailSize) }},
+		{"int/AppendedInt", func(idx int) { AppendedInt(ints[idx], tailSize) }},
+		{"int/AppendedOrdered", func(idx int) { AppendedOrdered(ints[idx], tailSize) }},
+		{"float64/Appended", func(idx int) { Appended(stdsort.Float64Slice(floats[idx]), tailSize) }},
+		{"float64/AppendedFloat64", func(idx int) { AppendedFloat64(floats[idx], tailSize) }},
+		{"float64/AppendedOrdered", func(idx int) { AppendedOrdered(floats[idx], tailSize) }},
+		{"string/Appended", func(idx int) { Appended(stdsort.StringSlice(strs[idx]), tailSize) }},
+		{"string/AppendedString", func(idx int) { AppendedString(strs[idx], tailSize) }},
+		{"string/AppendedOrdered", func(idx int) { AppendedOrdered(strs[idx], tailSize) }},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				idx := i % csCount
+				if idx == 0 {
+					reset(b)
+				}
+				bc.fn(idx)
+			}
+		})
+	}
+}
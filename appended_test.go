@@ -5,8 +5,13 @@
 package xsort
 
 import (
+	"cmp"
+	"encoding/binary"
 	"fmt"
+	"math"
+	"math/bits"
 	"math/rand"
+	stdslices "slices"
 	stdsort "sort"
 	"strings"
 	"testing"
@@ -130,6 +135,52 @@ func FuzzAppended3(f *testing.F) {
 	})
 }
 
+// testAppendedEquivalence checks that groupInsertAppendSort and
+// groupInsertAppendSortWithBuf (two implementations of the same idea)
+// give the same result on the same input. The elements with equal keys
+// are distinguishable, so a lost or a duplicated element is detected
+// even if the keys are in order.
+func testAppendedEquivalence(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	inPlace := make(keySeqs, len(s))
+	for idx, v := range s {
+		inPlace[idx] = keySeq{Key: v, Seq: idx}
+	}
+	withBuf := append(keySeqs{}, inPlace...)
+	t.Run(testName, func(t *testing.T) {
+		groupInsertAppendSort(inPlace, tailLenght)
+		groupInsertAppendSortWithBuf(withBuf, make([]keySeq, tailLenght))
+
+		seenInPlace := make([]bool, len(s))
+		seenWithBuf := make([]bool, len(s))
+		for idx := range s {
+			if inPlace[idx].Key != withBuf[idx].Key {
+				t.Fatalf("%v != %v; testCase < %s , %s >", inPlace, withBuf, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+			}
+			if seenInPlace[inPlace[idx].Seq] || seenWithBuf[withBuf[idx].Seq] {
+				t.Fatalf("a duplicated element: %v, %v; testCase < %s , %s >", inPlace, withBuf, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+			}
+			seenInPlace[inPlace[idx].Seq] = true
+			seenWithBuf[withBuf[idx].Seq] = true
+		}
+	})
+}
+
+func TestAppendedEquivalence(t *testing.T) {
+	testAppendedEquivalence(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedEquivalence(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedEquivalence(t, []byte{49, 255, 127}, 2)
+	testAppendedEquivalence(t, []byte{65, 76, 173, 37, 67, 145}, 5)
+	testAppendedEquivalence(t, []byte{1, 1, 1, 1, 1, 1, 0, 1, 0}, 3)
+}
+
+func FuzzAppendedEquivalence(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedEquivalence(t, initial, tailLenght)
+	})
+}
+
 type intSlice []int
 
 func (s intSlice) Less(i, j int) bool {
@@ -277,3 +328,328 @@ func BenchmarkAppended(b *testing.B) {
 		}
 	}
 }
+
+func TestAppendedTailAfterPrefix(t *testing.T) {
+	testAppended(t, []byte{1, 3, 5, 7, 11, 13, 15, 14, 13, 20}, 4)
+	testAppended(t, []byte{1, 3, 5, 7, 11, 13, 15, 14, 12, 20}, 4)
+	testAppended(t, []byte{13, 15, 14, 20}, 4)
+	testAppended2(t, []byte{1, 3, 5, 7, 11, 13, 15, 14, 13, 20}, 4)
+	testAppended2(t, []byte{1, 3, 5, 7, 11, 13, 15, 14, 12, 20}, 4)
+	testAppended2(t, []byte{13, 15, 14, 20}, 4)
+}
+
+func BenchmarkAppendedTailAfterPrefix(b *testing.B) {
+	const csCount = 20
+	for _, totalSize := range []int{1024, 65536, 1024 * 1024} {
+		for _, tailSize := range []int{16, 1024} {
+			rng := rand.New(rand.NewSource(0))
+			in := make([][]int, csCount)
+			for idx := range in {
+				in[idx] = make([]int, totalSize)
+				s := in[idx]
+				splitIdx := totalSize - tailSize
+				for idx := range s[:splitIdx] {
+					s[idx] = rng.Intn(totalSize)
+				}
+				stdsort.Ints(s[:splitIdx])
+				for idx := splitIdx; idx < totalSize; idx++ {
+					s[idx] = totalSize + rng.Intn(totalSize)
+				}
+			}
+
+			cs := make([]intSlice, csCount)
+			for idx := range cs {
+				cs[idx] = make([]int, totalSize)
+			}
+
+			b.Run(fmt.Sprintf("total-%d/tail-%d", totalSize, tailSize), func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range cs {
+							copy(cs[idx], in[idx])
+						}
+						b.StartTimer()
+					}
+					Appended(cs[idx], uint(tailSize))
+				}
+			})
+		}
+	}
+}
+
+func TestAppendedClamped(t *testing.T) {
+	s := intSlice{5, 3, 1, 4, 2}
+	AppendedClamped(s, uint(len(s)+5))
+	if !intsEqual(s, []int{1, 2, 3, 4, 5}) {
+		t.Fatalf("unexpected result: %v", s)
+	}
+
+	s = intSlice{1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 4, 2}
+	AppendedClamped(s, 2)
+	if !intsEqual(s, []int{1, 2, 3, 4, 5, 7, 9, 11, 13, 15, 17, 19}) {
+		t.Fatalf("unexpected result: %v", s)
+	}
+
+	AppendedClamped(intSlice{}, 5)
+}
+
+type int64Pair struct {
+	A, B int64
+}
+
+type int64Pairs []int64Pair
+
+func (s int64Pairs) Less(i, j int) bool {
+	if s[i].A != s[j].A {
+		return s[i].A < s[j].A
+	}
+	return s[i].B < s[j].B
+}
+
+// BenchmarkAppendedElemTypes is similar to BenchmarkAppended, but compares
+// different element types (to see how the element size affects the in-place
+// rotations and the buffered copying).
+func BenchmarkAppendedElemTypes(b *testing.B) {
+	benchmarkAppendedElemType[int, intSlice](b, "int", func(rng *rand.Rand, max int) int {
+		return rng.Intn(max)
+	})
+	benchmarkAppendedElemType[int64Pair, int64Pairs](b, "struct", func(rng *rand.Rand, max int) int64Pair {
+		return int64Pair{A: int64(rng.Intn(max)), B: int64(rng.Intn(max))}
+	})
+	benchmarkAppendedElemType[string, stdsort.StringSlice](b, "string", func(rng *rand.Rand, max int) string {
+		return fmt.Sprintf("%016d", rng.Intn(max))
+	})
+}
+
+func benchmarkAppendedElemType[E any, S Interface[E]](b *testing.B, typeName string, randomValue func(rng *rand.Rand, max int) E) {
+	const (
+		totalSize = 1024 * 1024
+		csCount   = 4
+	)
+	for _, tailSize := range []int{16, 256, 4096, 65536} {
+		rng := rand.New(rand.NewSource(0))
+		in := make([]S, csCount)
+		for idx := range in {
+			in[idx] = make(S, totalSize)
+			s := in[idx]
+			for idx := range s {
+				s[idx] = randomValue(rng, totalSize)
+			}
+			sort.Sort(s[:totalSize-tailSize])
+		}
+
+		cs := make([]S, csCount)
+		for idx := range cs {
+			cs[idx] = make(S, totalSize)
+		}
+
+		buf := make([]E, tailSize)
+		for _, f := range []struct {
+			name string
+			fn   func(S)
+		}{
+			{name: "Sort", fn: func(s S) { sort.Sort(s) }},
+			{name: "Appended", fn: func(s S) { Appended(s, uint(tailSize)) }},
+			{name: "AppendedWithBuf", fn: func(s S) { AppendedWithBuf(s, buf) }},
+		} {
+			b.Run(fmt.Sprintf("type-%s/total-%d/tail-%d/%s", typeName, totalSize, tailSize, f.name), func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range cs {
+							copy(cs[idx], in[idx])
+						}
+						b.StartTimer()
+					}
+					f.fn(cs[idx])
+				}
+			})
+		}
+	}
+}
+
+func TestSortTailDescending(t *testing.T) {
+	t.Run("unsorted", func(t *testing.T) {
+		s := intSlice{3, 1, 4, 1, 5, 9, 2, 6}
+		sortTailDescending(s)
+		if !intsEqual(s, []int{9, 6, 5, 4, 3, 2, 1, 1}) {
+			t.Fatalf("the tail is expected to be sorted in descending order: %v", s)
+		}
+	})
+
+	t.Run("already_descending", func(t *testing.T) {
+		// An already descending tail is left untouched (the order of
+		// equal elements is not changed).
+		s := make(keySeqs, 100)
+		for idx := range s {
+			s[idx] = keySeq{Key: (len(s) - idx) / 10, Seq: idx}
+		}
+		sortTailDescending(s)
+		for idx := range s {
+			if s[idx].Seq != idx {
+				t.Fatalf("the tail is not expected to be changed: %v", s)
+			}
+		}
+	})
+
+	t.Run("merge_requires_descending_tail", func(t *testing.T) {
+		s := intSlice{1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 12, 6, 4, 2}
+		sortTailDescending(s[10:])
+		if !intsEqual(s[10:], []int{12, 6, 4, 2}) {
+			t.Fatalf("the tail is expected to be sorted in descending order: %v", s[10:])
+		}
+		groupInsertDescendingTail(s, 10)
+		if !intsEqual(s, []int{1, 2, 3, 4, 5, 6, 7, 9, 11, 12, 13, 15, 17, 19}) {
+			t.Fatalf("unexpected result: %v", s)
+		}
+	})
+}
+
+type uint16Slice []uint16
+
+func (s uint16Slice) Less(i, j int) bool {
+	return s[i] < s[j]
+}
+
+type int32Slice []int32
+
+func (s int32Slice) Less(i, j int) bool {
+	return s[i] < s[j]
+}
+
+func testAppendedWithBufElemType[E cmp.Ordered, S Interface[E]](t *testing.T, s S, tailLenght uint) {
+	splitIdx := len(s) - int(tailLenght)
+	stdslices.Sort(s[:splitIdx])
+	c := make([]E, len(s))
+	copy(c, s)
+	t.Run(fmt.Sprintf("%T/%v (tailLength: %d)", s, s, tailLenght), func(t *testing.T) {
+		AppendedWithBuf(s, make([]E, tailLenght))
+		stdslices.Sort(c)
+		if !stdslices.Equal(c, s) {
+			t.Fatalf("%v != %v", c, s)
+		}
+	})
+}
+
+// FuzzAppendedWithBufElemWidth is similar to FuzzAppended3, but it
+// reinterprets the bytes as uint16 or int32 values (depending on the second
+// argument) to cover the element-size dependent code paths.
+func FuzzAppendedWithBufElemWidth(f *testing.F) {
+	f.Add([]byte{1, 0, 3, 0, 5, 0, 7, 0, 11, 0, 13, 0, 12, 0, 6, 0, 4, 0, 8, 0}, []byte{0})
+	f.Add([]byte{1, 0, 3, 0, 5, 0, 7, 0, 11, 0, 13, 0, 12, 0, 6, 0, 4, 0, 8, 0}, []byte{1})
+	f.Fuzz(func(t *testing.T, initial, elemWidth []byte) {
+		if len(elemWidth) > 0 && elemWidth[0]%2 == 1 {
+			s := make(int32Slice, len(initial)/4)
+			for idx := range s {
+				s[idx] = int32(binary.LittleEndian.Uint32(initial[idx*4:]))
+			}
+			testAppendedWithBufElemType[int32](t, s, uint(rand.Intn(len(s)+1)))
+			return
+		}
+		s := make(uint16Slice, len(initial)/2)
+		for idx := range s {
+			s[idx] = binary.LittleEndian.Uint16(initial[idx*2:])
+		}
+		testAppendedWithBufElemType[uint16](t, s, uint(rand.Intn(len(s)+1)))
+	})
+}
+
+func TestAppendedWithBufN(t *testing.T) {
+	scratch := make([]int, 100)
+
+	s := intSlice{1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 4, 2}
+	AppendedWithBufN(s, scratch, 2)
+	if !intsEqual(s, []int{1, 2, 3, 4, 5, 7, 9, 11, 13, 15, 17, 19}) {
+		t.Fatalf("unexpected result: %v", s)
+	}
+
+	// the capacity is enough
+	s = intSlice{1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 6, 4, 2}
+	AppendedWithBufN(s, scratch[:0], 3)
+	if !intsEqual(s, []int{1, 2, 3, 4, 5, 6, 7, 9, 11, 13, 15, 17, 19}) {
+		t.Fatalf("unexpected result: %v", s)
+	}
+
+	t.Run("small_buffer", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		AppendedWithBufN(intSlice{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 2, 1}, make([]int, 1), 2)
+	})
+}
+
+func TestAppendedBoundaries(t *testing.T) {
+	// tailLength == len(s)-1
+	for _, length := range []int{2, 3, 10, 1000} {
+		s := make(intSlice, length)
+		for idx := range s {
+			s[idx] = length - idx
+		}
+		Appended(s, uint(length-1))
+		if !stdsort.IntsAreSorted(s) {
+			t.Fatalf("not sorted: %v", s)
+		}
+
+		for idx := range s {
+			s[idx] = length - idx
+		}
+		AppendedWithBuf(s, make([]int, length-1))
+		if !stdsort.IntsAreSorted(s) {
+			t.Fatalf("not sorted: %v", s)
+		}
+	}
+
+	for _, tailLength := range []uint{11, math.MaxInt, math.MaxInt + 1, math.MaxUint} {
+		t.Run(fmt.Sprintf("tailLength-%d", tailLength), func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("expected a panic")
+				}
+			}()
+			Appended(make(intSlice, 10), tailLength)
+		})
+	}
+}
+
+func TestShouldUseAppendedOverflow(t *testing.T) {
+	for _, testCase := range []struct {
+		totalSize uint
+		tailSize  uint
+		expected  bool
+	}{
+		{totalSize: math.MaxInt, tailSize: 1 << (bits.UintSize / 4), expected: true},
+		{totalSize: math.MaxInt, tailSize: 1 << (bits.UintSize/2 + 1), expected: true},
+		{totalSize: math.MaxInt, tailSize: 1 << (bits.UintSize/2 + 3), expected: false},
+		{totalSize: math.MaxInt, tailSize: math.MaxInt, expected: false},
+		{totalSize: 1 << 20, tailSize: math.MaxUint, expected: false},
+	} {
+		r := shouldUseAppended(testCase.totalSize, testCase.tailSize)
+		if r != testCase.expected {
+			t.Errorf("shouldUseAppended(%d, %d): %t != %t", testCase.totalSize, testCase.tailSize, r, testCase.expected)
+		}
+	}
+
+	for _, testCase := range []struct {
+		totalSize uint
+		tailSize  uint
+		expected  bool
+	}{
+		{totalSize: math.MaxInt, tailSize: math.MaxInt / 2, expected: true},
+		{totalSize: math.MaxInt, tailSize: math.MaxInt, expected: false},
+		{totalSize: 1 << 20, tailSize: math.MaxUint, expected: false},
+	} {
+		r := shouldUseAppendedWithBuf(testCase.totalSize, testCase.tailSize)
+		if r != testCase.expected {
+			t.Errorf("shouldUseAppendedWithBuf(%d, %d): %t != %t", testCase.totalSize, testCase.tailSize, r, testCase.expected)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"math/bits"
+	"reflect"
+	"sync"
+)
+
+const (
+	// autoBufMaxBytes is the maximal size (in bytes) of a buffer kept in
+	// the pool of AppendedAutoBuf. Bigger buffers are allocated on each
+	// call.
+	autoBufMaxBytes = 1 << 20
+
+	// autoBufsPerClass is the maximal amount of buffers of the same type
+	// and the same size class kept in the pool of AppendedAutoBuf.
+	autoBufsPerClass = 4
+)
+
+// autoBufPools contains an *autoBufPool[E] per element type.
+var autoBufPools sync.Map
+
+// autoBufPool is a pool of buffers of a single element type.
+// The buffers are grouped by size classes: class `c` contains buffers
+// of capacity `1<<c`.
+type autoBufPool[E any] struct {
+	classes     []autoBufClass[E]
+	hasPointers bool
+}
+
+// autoBufClass is the pooled buffers of a single size class.
+type autoBufClass[E any] struct {
+	// bounded keeps at most autoBufsPerClass buffers (see AppendedAutoBuf).
+	bounded chan []E
+
+	// released keeps any amount of buffers until they are released by
+	// the garbage collector (see AppendedBufFree).
+	released sync.Pool
+}
+
+// AppendedAutoBuf is the same as AppendedWithBuf, but the buffer is taken
+// from a package-level pool (and returned back after the sort), so
+// repeated calls in a hot loop do not allocate.
+//
+// The pool is bounded: it keeps at most 4 buffers per element type and
+// per power-of-two size class, and buffers bigger than 1MiB are not kept
+// at all (the pool is shared with AppendedBufFree, which adds an unbounded
+// part released by the garbage collector). Buffers of types containing pointers are zeroed before being
+// returned to the pool (see AppendedWithBufClear).
+//
+// It is safe for concurrent use.
+//
+// T: O(k*ln(n) + n)
+//
+// S: O(k) [if without `s`]
+func AppendedAutoBuf[E any, S Interface[E]](s S, tailLength uint) {
+	strategy := startAppended(s, tailLength, appendedPolicy{shouldUse: shouldUseAppendedWithBuf})
+	if strategy != StrategyGroupInsert {
+		// the buffer is not needed
+		finishAppended(s, tailLength, strategy)
+		return
+	}
+	pool := getAutoBufPool[E]()
+	buf := pool.get(int(tailLength))
+	groupInsertAppendSortWithBuf(s, buf)
+	pool.put(buf, true)
+}
+
+func getAutoBufPool[E any]() *autoBufPool[E] {
+	typ := reflect.TypeOf((*E)(nil)).Elem()
+	if pool, ok := autoBufPools.Load(typ); ok {
+		return pool.(*autoBufPool[E])
+	}
+
+	// zero-sized buffers are not allocated anyway, so they are not pooled
+	classCount := 0
+	if typ.Size() > 0 {
+		classCount = bits.Len(uint(autoBufMaxBytes / typ.Size()))
+	}
+	pool := &autoBufPool[E]{
+		classes:     make([]autoBufClass[E], classCount),
+		hasPointers: typeHasPointers(typ),
+	}
+	for idx := range pool.classes {
+		pool.classes[idx].bounded = make(chan []E, autoBufsPerClass)
+	}
+	actual, _ := autoBufPools.LoadOrStore(typ, pool)
+	return actual.(*autoBufPool[E])
+}
+
+// get returns a buffer of the given length.
+func (p *autoBufPool[E]) get(length int) []E {
+	class := bits.Len(uint(length - 1))
+	if class >= len(p.classes) {
+		return make([]E, length)
+	}
+	select {
+	case buf := <-p.classes[class].bounded:
+		return buf[:length]
+	default:
+	}
+	if buf, ok := p.classes[class].released.Get().(*[]E); ok {
+		return (*buf)[:length]
+	}
+	return make([]E, length, 1<<class)
+}
+
+// put returns the buffer to the pool (if it is not too big). If bounded
+// is true, then the buffer is kept only if there is a room for it in
+// the bounded part of the pool, otherwise it is kept until the garbage
+// collector releases it.
+func (p *autoBufPool[E]) put(buf []E, bounded bool) {
+	class := bits.Len(uint(cap(buf) - 1))
+	if class >= len(p.classes) || cap(buf) != 1<<class {
+		return
+	}
+	if p.hasPointers {
+		clear(buf[:cap(buf)])
+	}
+	if !bounded {
+		p.classes[class].release(buf)
+		return
+	}
+	select {
+	case p.classes[class].bounded <- buf:
+	default:
+	}
+}
+
+// release puts the buffer to the unbounded part of the class.
+func (c *autoBufClass[E]) release(buf []E) {
+	// a separate function, so only the released buffers escape to the heap
+	c.released.Put(&buf)
+}
@@ -0,0 +1,151 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func testAppendedAutoBuf(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		AppendedAutoBuf(intSlice(s), tailLenght)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedAutoBuf(t *testing.T) {
+	testAppendedAutoBuf(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedAutoBuf(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedAutoBuf(t, []byte{5, 4, 3, 2, 1}, 5)
+
+	t.Run("zero_allocs", func(t *testing.T) {
+		in := make([]int, 4096)
+		for idx := range in {
+			in[idx] = idx * 2
+		}
+		s := make(intSlice, len(in))
+		allocs := testing.AllocsPerRun(100, func() {
+			copy(s, in)
+			for idx := len(s) - 100; idx < len(s); idx++ {
+				s[idx] = len(s) - idx
+			}
+			AppendedAutoBuf(s, 100)
+		})
+		if allocs != 0 {
+			t.Fatalf("unexpected allocations: %v", allocs)
+		}
+		if !stdsort.IntsAreSorted(s) {
+			t.Fatalf("not sorted")
+		}
+	})
+
+	t.Run("bounded", func(t *testing.T) {
+		pool := getAutoBufPool[bigElem]()
+		maxLength := 1 << (len(pool.classes) - 1)
+		if maxLength*int(unsafe.Sizeof(bigElem{})) > autoBufMaxBytes {
+			t.Fatalf("the biggest pooled buffer is too big: %d elements", maxLength)
+		}
+
+		big := pool.get(maxLength + 1)
+		pool.put(big, true)
+		if buf := pool.get(maxLength + 1); &buf[0] == &big[0] {
+			t.Fatal("a too big buffer is pooled")
+		}
+
+		var bufs [][]bigElem
+		for idx := 0; idx < autoBufsPerClass+1; idx++ {
+			bufs = append(bufs, pool.get(10))
+		}
+		for _, buf := range bufs {
+			pool.put(buf, true)
+		}
+		if l := len(pool.classes[4].bounded); l != autoBufsPerClass {
+			t.Fatalf("unexpected amount of pooled buffers: %d", l)
+		}
+	})
+
+	t.Run("cleared", func(t *testing.T) {
+		pool := getAutoBufPool[*int]()
+		buf := pool.get(3)
+		for idx := range buf {
+			buf[idx] = new(int)
+		}
+		pool.put(buf, true)
+		buf = pool.get(4)
+		for idx, ptr := range buf {
+			if ptr != nil {
+				t.Fatalf("the buffer is not cleared at %d", idx)
+			}
+		}
+	})
+}
+
+func FuzzAppendedAutoBuf(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedAutoBuf(t, initial, tailLenght)
+	})
+}
+
+func BenchmarkAppendedAutoBuf(b *testing.B) {
+	const (
+		totalSize = 65536
+		csCount   = 20
+	)
+	for _, tailSize := range []int{16, 1024} {
+		rng := rand.New(rand.NewSource(0))
+		in := make([][]int, csCount)
+		for idx := range in {
+			in[idx] = make([]int, totalSize)
+			s := in[idx]
+			for idx := range s {
+				s[idx] = rng.Intn(totalSize)
+			}
+			stdsort.Ints(s[:totalSize-tailSize])
+		}
+
+		cs := make([]intSlice, csCount)
+		for idx := range cs {
+			cs[idx] = make([]int, totalSize)
+		}
+
+		for _, f := range []struct {
+			name string
+			fn   func(intSlice, uint)
+		}{
+			{name: "AppendedWithBuf-make", fn: func(s intSlice, tailLength uint) {
+				AppendedWithBuf(s, make([]int, tailLength))
+			}},
+			{name: "AppendedAutoBuf", fn: AppendedAutoBuf[int, intSlice]},
+		} {
+			b.Run(fmt.Sprintf("total-%d/tail-%d/%s", totalSize, tailSize, f.name), func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range cs {
+							copy(cs[idx], in[idx])
+						}
+						b.StartTimer()
+					}
+					f.fn(cs[idx], uint(tailSize))
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// SortManyAppended calls Appended for every slice of the batch, using
+// the tail length with the same index from tailLengths.
+//
+// If workers is greater than one, then the slices are distributed among
+// up to `workers` goroutines. In this case the slices must not overlap
+// with each other.
+//
+// The function panics if the lengths of batch and tailLengths differ
+// or if any of the tail lengths is greater than the length of its slice.
+// All the checks are performed before any slice is modified.
+func SortManyAppended[E any, S Interface[E]](batch []S, tailLengths []uint, workers int) {
+	if len(batch) != len(tailLengths) {
+		panic(fmt.Sprintf("the amount of tail lengths (%d) is not equal to the amount of slices (%d)", len(tailLengths), len(batch)))
+	}
+	for idx, s := range batch {
+		if tailLengths[idx] > uint(len(s)) {
+			panic(fmt.Sprintf("tailLength (%d) cannot be greater than the length of the provided slice (%d) at index %d", tailLengths[idx], len(s), idx))
+		}
+	}
+
+	if workers > len(batch) {
+		workers = len(batch)
+	}
+	if workers <= 1 {
+		for idx, s := range batch {
+			Appended(s, tailLengths[idx])
+		}
+		return
+	}
+
+	var (
+		wg      sync.WaitGroup
+		nextIdx int64
+	)
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				idx := int(atomic.AddInt64(&nextIdx, 1) - 1)
+				if idx >= len(batch) {
+					return
+				}
+				Appended(batch[idx], tailLengths[idx])
+			}
+		}()
+	}
+	wg.Wait()
+}
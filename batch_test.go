@@ -0,0 +1,68 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"testing"
+)
+
+func prepareBatch(rng *rand.Rand, count int) ([]intSlice, []uint, [][]int) {
+	batch := make([]intSlice, count)
+	tailLengths := make([]uint, count)
+	expected := make([][]int, count)
+	for idx := range batch {
+		s := make([]int, rng.Intn(300))
+		for i := range s {
+			s[i] = rng.Intn(100)
+		}
+		tailLength := rng.Intn(len(s) + 1)
+		stdsort.Ints(s[:len(s)-tailLength])
+
+		batch[idx] = s
+		tailLengths[idx] = uint(tailLength)
+		expected[idx] = make([]int, len(s))
+		copy(expected[idx], s)
+		stdsort.Ints(expected[idx])
+	}
+	return batch, tailLengths, expected
+}
+
+func TestSortManyAppended(t *testing.T) {
+	for _, workers := range []int{0, 1, 4, 100} {
+		t.Run(fmt.Sprintf("workers-%d", workers), func(t *testing.T) {
+			rng := rand.New(rand.NewSource(0))
+			batch, tailLengths, expected := prepareBatch(rng, 50)
+			SortManyAppended(batch, tailLengths, workers)
+			for idx := range batch {
+				if !intsEqual(expected[idx], batch[idx]) {
+					t.Fatalf("%v != %v; idx: %d", expected[idx], batch[idx], idx)
+				}
+			}
+		})
+	}
+}
+
+func TestSortManyAppendedInvalidInput(t *testing.T) {
+	for name, tailLengths := range map[string][]uint{
+		"length-mismatch": {0},
+		"tail-too-long":   {0, 4},
+	} {
+		t.Run(name, func(t *testing.T) {
+			batch := []intSlice{{1, 2}, {3, 2, 1}}
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected a panic")
+				}
+				if !intsEqual(batch[1], []int{3, 2, 1}) {
+					t.Fatalf("the slice was modified: %v", batch[1])
+				}
+			}()
+			SortManyAppended(batch, tailLengths, 2)
+		})
+	}
+}
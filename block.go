@@ -0,0 +1,62 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+)
+
+// SwapRange swaps n consecutive elements starting at index i with
+// n consecutive elements starting at index j.
+//
+// The ranges must not overlap (unless i == j, then nothing is done),
+// otherwise it panics. To move overlapping ranges use a rotation (see
+// package `github.com/go-ng/slices`).
+//
+// T: O(n)
+//
+// S: O(1)
+func SwapRange[E any, S Interface[E]](s S, i, j, n int) {
+	checkRange(len(s), i, n)
+	checkRange(len(s), j, n)
+	if i == j || n == 0 {
+		return
+	}
+	if i < j+n && j < i+n {
+		panic(fmt.Sprintf("the ranges [%d, %d) and [%d, %d) overlap", i, i+n, j, j+n))
+	}
+	for idx := 0; idx < n; idx++ {
+		s[i+idx], s[j+idx] = s[j+idx], s[i+idx]
+	}
+}
+
+// LessRange returns true if n consecutive elements starting at index i
+// are lexicographically less than n consecutive elements starting at
+// index j (in terms of s.Less). The ranges may overlap.
+//
+// T: O(n)
+//
+// S: O(1)
+func LessRange[E any, S Interface[E]](s S, i, j, n int) bool {
+	checkRange(len(s), i, n)
+	checkRange(len(s), j, n)
+	for idx := 0; idx < n; idx++ {
+		if s.Less(i+idx, j+idx) {
+			return true
+		}
+		if s.Less(j+idx, i+idx) {
+			return false
+		}
+	}
+	return false
+}
+
+// checkRange panics if [start, start+n) is not a valid range of a slice
+// of the given length.
+func checkRange(length, start, n int) {
+	if start < 0 || n < 0 || start > length || n > length-start {
+		panic(fmt.Sprintf("the range [%d, %d+%d) is out of the slice of length %d", start, start, n, length))
+	}
+}
@@ -0,0 +1,99 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"github.com/go-ng/slices"
+	"github.com/go-ng/sort"
+)
+
+// AppendedBlockMerge is the same as Appended, but it sorts the tail and
+// then merges it into the prefix in place, using the block-rotation merge
+// "SymMerge" (by Pok-Son Kim and Arne Kutzner, the same as in the standard
+// `sort.Stable`). Thus unlike AppendedWithBuf it requires no buffer, and
+// unlike Appended it does not degrade if the tail is long.
+//
+// It is an alternative to a full resort if the tail is too long for
+// Appended (for example comparable to the prefix).
+//
+// T: O(k*ln(k) + n*ln(n)) [the merge is O(k*ln(n/k+1)) comparisons]
+//
+// S: O(ln(n)) [if without `s`]
+func AppendedBlockMerge[E any, S Interface[E]](s S, tailLength uint) {
+	strategy := startAppended(s, tailLength, appendedPolicy{shouldUse: alwaysUseAppended})
+	if strategy != StrategyGroupInsert {
+		finishAppended(s, tailLength, strategy)
+		return
+	}
+
+	splitIdx := len(s) - int(tailLength)
+	sort.Sort(s[splitIdx:])
+	symMerge(s, 0, splitIdx, len(s))
+}
+
+// symMerge merges the sorted ranges s[a:m] and s[m:b] in place (stably).
+// It is the same as `symMerge` of the standard `sort` package, but moves
+// the elements by rotations.
+func symMerge[E any, S Interface[E]](s S, a, m, b int) {
+	if m-a == 1 {
+		// insert s[a] into s[m:b]
+		i, j := m, b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if s.Less(h, a) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		slices.Rotate(s[a:i], -1)
+		return
+	}
+	if b-m == 1 {
+		// insert s[m] into s[a:m]
+		i, j := a, m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !s.Less(m, h) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		slices.Rotate(s[i:m+1], 1)
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !s.Less(p-c, c) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		slices.Rotate(s[start:end], end-m)
+	}
+	if a < start && start < mid {
+		symMerge(s, a, start, mid)
+	}
+	if mid < end && end < b {
+		symMerge(s, mid, end, b)
+	}
+}
@@ -0,0 +1,118 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+func testAppendedBlockMerge(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		AppendedBlockMerge(intSlice(s), tailLenght)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedBlockMerge(t *testing.T) {
+	testAppendedBlockMerge(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedBlockMerge(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedBlockMerge(t, []byte{5, 4, 3, 2, 1}, 5)
+	testAppendedBlockMerge(t, []byte{9, 1, 8, 2, 7, 3, 6, 4, 5}, 8)
+	testAppendedBlockMerge(t, []byte{1, 2, 3, 4, 0}, 1)
+
+	t.Run("stable_merge", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(0))
+		for run := 0; run < 100; run++ {
+			s := make(keySeqs, 1+rng.Intn(200))
+			splitIdx := rng.Intn(len(s) + 1)
+			for idx := range s {
+				s[idx] = keySeq{Key: rng.Intn(10), Seq: idx}
+			}
+			stdsort.SliceStable(s[:splitIdx], func(i, j int) bool {
+				return s[i].Key < s[j].Key
+			})
+			stdsort.SliceStable(s[splitIdx:], func(i, j int) bool {
+				return s[splitIdx+i].Key < s[splitIdx+j].Key
+			})
+			expected := append(keySeqs{}, s...)
+			stdsort.SliceStable(expected, func(i, j int) bool {
+				return expected[i].Key < expected[j].Key
+			})
+
+			if splitIdx > 0 && splitIdx < len(s) {
+				symMerge(s, 0, splitIdx, len(s))
+			}
+			for idx := range expected {
+				if s[idx] != expected[idx] {
+					t.Fatalf("%v != %v", s, expected)
+				}
+			}
+		}
+	})
+}
+
+func FuzzAppendedBlockMerge(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedBlockMerge(t, initial, tailLenght)
+	})
+}
+
+func BenchmarkAppendedBlockMerge(b *testing.B) {
+	const (
+		totalSize = 65536
+		csCount   = 20
+	)
+	for _, tailSize := range []int{16, 1024, 8192, 32768} {
+		rng := rand.New(rand.NewSource(0))
+		in := make([][]int, csCount)
+		for idx := range in {
+			in[idx] = make([]int, totalSize)
+			s := in[idx]
+			for idx := range s {
+				s[idx] = rng.Intn(totalSize)
+			}
+			stdsort.Ints(s[:totalSize-tailSize])
+		}
+		cs := make([]intSlice, csCount)
+		for idx := range cs {
+			cs[idx] = make([]int, totalSize)
+		}
+		buf := make([]int, tailSize)
+
+		for _, f := range []struct {
+			name string
+			fn   func(intSlice)
+		}{
+			{name: "Appended", fn: func(s intSlice) { Appended(s, uint(tailSize)) }},
+			{name: "AppendedWithBuf", fn: func(s intSlice) { AppendedWithBuf(s, buf) }},
+			{name: "AppendedBlockMerge", fn: func(s intSlice) { AppendedBlockMerge(s, uint(tailSize)) }},
+		} {
+			b.Run(fmt.Sprintf("total-%d/tail-%d/%s", totalSize, tailSize, f.name), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range cs {
+							copy(cs[idx], in[idx])
+						}
+						b.StartTimer()
+					}
+					f.fn(cs[idx])
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSwapRange(t *testing.T) {
+	for _, testCase := range []struct {
+		i, j, n  int
+		expected []int
+	}{
+		{0, 3, 3, []int{3, 4, 5, 0, 1, 2, 6}},
+		{4, 0, 2, []int{4, 5, 2, 3, 0, 1, 6}},
+		{0, 6, 1, []int{6, 1, 2, 3, 4, 5, 0}},
+		{2, 2, 3, []int{0, 1, 2, 3, 4, 5, 6}},
+		{1, 5, 0, []int{0, 1, 2, 3, 4, 5, 6}},
+		{7, 0, 0, []int{0, 1, 2, 3, 4, 5, 6}},
+	} {
+		t.Run(fmt.Sprintf("%d_%d_%d", testCase.i, testCase.j, testCase.n), func(t *testing.T) {
+			s := intSlice{0, 1, 2, 3, 4, 5, 6}
+			SwapRange(s, testCase.i, testCase.j, testCase.n)
+			if !Equal(s, testCase.expected) {
+				t.Fatalf("%v != %v", s, testCase.expected)
+			}
+		})
+	}
+
+	for _, testCase := range [][3]int{
+		{0, 2, 3},           // overlap
+		{3, 1, 3},           // overlap
+		{0, 5, 3},           // out of range
+		{-1, 3, 1},          // negative index
+		{0, 3, -1},          // negative length
+		{0, 3, math.MaxInt}, // overflow
+	} {
+		t.Run(fmt.Sprintf("panic_%d_%d_%d", testCase[0], testCase[1], testCase[2]), func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("expected a panic")
+				}
+			}()
+			SwapRange(intSlice{0, 1, 2, 3, 4, 5, 6}, testCase[0], testCase[1], testCase[2])
+		})
+	}
+}
+
+func TestLessRange(t *testing.T) {
+	s := intSlice{1, 2, 3, 1, 2, 4, 1, 2}
+	for _, testCase := range []struct {
+		i, j, n  int
+		expected bool
+	}{
+		{0, 3, 3, true},
+		{3, 0, 3, false},
+		{0, 3, 2, false},
+		{0, 6, 2, false},
+		{0, 1, 2, true},  // overlapping
+		{1, 0, 2, false}, // overlapping
+		{0, 0, 8, false},
+		{2, 5, 0, false},
+	} {
+		t.Run(fmt.Sprintf("%d_%d_%d", testCase.i, testCase.j, testCase.n), func(t *testing.T) {
+			if result := LessRange(s, testCase.i, testCase.j, testCase.n); result != testCase.expected {
+				t.Fatalf("%v != %v", result, testCase.expected)
+			}
+		})
+	}
+}
+
+func FuzzSwapRange(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		if len(initial) < 2 {
+			return
+		}
+		n := rand.Intn(len(initial)/2) + 1
+		i := rand.Intn(len(initial) - 2*n + 1)
+		j := i + n + rand.Intn(len(initial)-i-2*n+1)
+		if rand.Intn(2) == 0 {
+			i, j = j, i
+		}
+
+		s := make(intSlice, len(initial))
+		for idx, v := range initial {
+			s[idx] = int(v)
+		}
+		orig := append(intSlice{}, s...)
+		SwapRange(s, i, j, n)
+		if !Equal(s[i:i+n], orig[j:j+n]) || !Equal(s[j:j+n], orig[i:i+n]) {
+			t.Fatalf("unexpected result of SwapRange(%d, %d, %d): %v -> %v", i, j, n, orig, s)
+		}
+		if LessRange(s, i, j, n) != LessRange(orig, j, i, n) {
+			t.Fatalf("LessRange is not consistent with SwapRange: %v -> %v", orig, s)
+		}
+	})
+}
+
+func BenchmarkSwapRange(b *testing.B) {
+	s := make(intSlice, 65536)
+	for _, n := range []int{16, 1024, 32768} {
+		b.Run(fmt.Sprintf("n-%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				SwapRange(s, 0, len(s)-n, n)
+			}
+		})
+	}
+}
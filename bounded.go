@@ -0,0 +1,47 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+)
+
+// AppendedBounded is the same as Appended, but it also accepts the
+// guarantee that none of the tail elements belongs more than
+// maxDisplacement positions before the beginning of the tail (a typical
+// case of time-series, where new points arrive slightly out of order).
+//
+// So only the last tailLength+maxDisplacement elements are sorted, which
+// limits the binary search window and the size of rotations.
+//
+// If the guarantee is violated, then the result is still correct, but
+// it takes more time.
+//
+// T: O(k*ln(k+d) + k + d + k^2) -- thus if `k` is too high then: O(k^2)
+//
+// S: O(1) [if without `s`]
+func AppendedBounded[E any, S Interface[E]](s S, tailLength uint, maxDisplacement int) {
+	if maxDisplacement < 0 {
+		panic(fmt.Sprintf("maxDisplacement (%d) cannot be negative", maxDisplacement))
+	}
+	checkTailLength(len(s), tailLength)
+	if tailLength == 0 {
+		return
+	}
+	windowStartIdx := len(s) - int(tailLength) - maxDisplacement
+	if windowStartIdx <= 0 {
+		Appended(s, tailLength)
+		return
+	}
+
+	Appended(s[windowStartIdx:], tailLength)
+	if !s.Less(windowStartIdx, windowStartIdx-1) {
+		return
+	}
+
+	// The guarantee is violated, s[:windowStartIdx] and s[windowStartIdx:]
+	// are two sorted parts.
+	Appended(s, uint(len(s)-windowStartIdx))
+}
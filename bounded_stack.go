@@ -0,0 +1,76 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"math/bits"
+)
+
+// AppendedBoundedStack is the same as Appended, but all the sorting (of
+// the tail and the fallback full sort) is done by an introsort with
+// an explicit fixed-size stack instead of recursion: the quicksort
+// switches to a heapsort once the depth exceeds `2*log2(n)`. Thus
+// the stack usage does not depend on the data, which is useful for
+// real-time systems.
+//
+// T: O(k*ln(n) + n + k^2) -- thus if `k` is too high then: O(n*ln(n))
+// (for any input).
+//
+// S: O(1) [if without `s`; the stack is a fixed-size array]
+func AppendedBoundedStack[E any, S Interface[E]](s S, tailLength uint) {
+	strategy := startAppended(s, tailLength, appendedPolicy{})
+	splitIdx := uint(len(s)) - tailLength
+	switch strategy {
+	case StrategyFallbackSort:
+		introSortBounded(s)
+	case StrategySortTail:
+		introSortBounded(s[splitIdx:])
+	case StrategyGroupInsert:
+		introSortBounded(descending[E, S](s[splitIdx:]))
+		groupInsertDescendingTail(s, splitIdx)
+	}
+}
+
+// introSortRange is a range of a slice pending to be sorted by
+// introSortBounded.
+type introSortRange struct {
+	lo, hi, depth int
+}
+
+// introSortBounded sorts the slice using a quicksort (with the median
+// of three pivot and a three-way partition), which switches to a heapsort
+// if the depth exceeds `2*log2(n)`. There is no recursion: the larger
+// part of each partition is put on a fixed-size stack, while the smaller
+// one is processed right away, so the stack never contains more than
+// `log2(n)` ranges.
+func introSortBounded[E any, S Interface[E]](s S) {
+	maxDepth := 2 * bits.Len(uint(len(s)))
+	var stack [bits.UintSize]introSortRange
+	stack[0] = introSortRange{lo: 0, hi: len(s)}
+	stackLen := 1
+	for stackLen > 0 {
+		stackLen--
+		lo, hi, depth := stack[stackLen].lo, stack[stackLen].hi, stack[stackLen].depth
+		for hi-lo > quickSortInsertionThreshold {
+			if depth >= maxDepth {
+				heapSort(s[lo:hi])
+				lo = hi
+				break
+			}
+			depth++
+			movePivotMedianOfThree(s, lo, hi)
+			eqStart, gt := partitionThreeWay(s, lo, hi)
+			if eqStart-lo < hi-gt {
+				stack[stackLen] = introSortRange{lo: gt, hi: hi, depth: depth}
+				hi = eqStart
+			} else {
+				stack[stackLen] = introSortRange{lo: lo, hi: eqStart, depth: depth}
+				lo = gt
+			}
+			stackLen++
+		}
+		insertionSort(s[lo:hi])
+	}
+}
@@ -0,0 +1,151 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/bits"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+func testAppendedBoundedStack(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		AppendedBoundedStack(intSlice(s), tailLenght)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedBoundedStack(t *testing.T) {
+	testAppendedBoundedStack(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedBoundedStack(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedBoundedStack(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 16, 18, 20, 21}, 4)
+	testAppendedBoundedStack(t, []byte{5, 4, 3, 2, 1, 5, 4, 3, 2, 1, 5, 4, 3, 2, 1, 0, 9}, 17)
+
+	initial := make([]byte, 10000)
+	rand.New(rand.NewSource(0)).Read(initial)
+	for _, tailLength := range []uint{10, 1000, 10000} {
+		testAppendedBoundedStack(t, append([]byte{}, initial...), tailLength)
+	}
+}
+
+func TestIntroSortBoundedAdversary(t *testing.T) {
+	// the McIlroy's adversary makes each median-of-three pivot bad, so
+	// a naive quicksort goes `n/2` levels deep (and does `O(n^2)`
+	// comparisons), while the introsort switches to the heapsort
+	for _, n := range []int{100, 1000, 5000} {
+		t.Run(fmt.Sprintf("n-%d", n), func(t *testing.T) {
+			adv := &antiQuickSort{values: make([]int, n), gas: n}
+			s := make(antiQuickSortSlice, n)
+			for idx := range s {
+				adv.values[idx] = adv.gas
+				s[idx] = antiQuickSortElem{id: idx, adv: adv}
+			}
+			killer := quickSortKiller(n)
+			naive, naiveComparisons := newCountedInts(killer)
+			quickSortRandomized(naive, nil)
+			if *naiveComparisons < n*n/8 {
+				t.Fatalf("the input is expected to be quadratic for the naive quicksort: %d", *naiveComparisons)
+			}
+
+			values, comparisons := newCountedInts(killer)
+			introSortBounded(values)
+			for idx := 1; idx < len(values); idx++ {
+				if values[idx].v < values[idx-1].v {
+					t.Fatalf("not sorted at %d", idx)
+				}
+			}
+			maxComparisons := 8 * n * bits.Len(uint(n))
+			if *comparisons > maxComparisons {
+				t.Fatalf("too many comparisons: %d > %d", *comparisons, maxComparisons)
+			}
+
+			// the adversary itself
+			introSortBounded(s)
+			for idx := 1; idx < len(s); idx++ {
+				if adv.values[s[idx].id] < adv.values[s[idx-1].id] {
+					t.Fatalf("not sorted at %d", idx)
+				}
+			}
+		})
+	}
+}
+
+func TestHeapSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	for _, n := range []int{0, 1, 2, 3, 10, 100, 1001} {
+		s := make(intSlice, n)
+		for idx := range s {
+			s[idx] = rng.Intn(n/2 + 1)
+		}
+		heapSort(s)
+		if !stdsort.IntsAreSorted(s) {
+			t.Fatalf("not sorted: %v", s)
+		}
+	}
+}
+
+func FuzzAppendedBoundedStack(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedBoundedStack(t, initial, tailLenght)
+	})
+}
+
+func BenchmarkAppendedBoundedStack(b *testing.B) {
+	const (
+		totalSize = 65536
+		csCount   = 20
+	)
+	for _, tailSize := range []int{1024, 65536} {
+		rng := rand.New(rand.NewSource(0))
+		in := make([][]int, csCount)
+		for idx := range in {
+			in[idx] = make([]int, totalSize)
+			s := in[idx]
+			for idx := range s {
+				s[idx] = rng.Intn(totalSize)
+			}
+			stdsort.Ints(s[:totalSize-tailSize])
+		}
+
+		cs := make([]intSlice, csCount)
+		for idx := range cs {
+			cs[idx] = make([]int, totalSize)
+		}
+
+		for _, f := range []struct {
+			name string
+			fn   func(intSlice, uint)
+		}{
+			{name: "Appended", fn: Appended[int, intSlice]},
+			{name: "AppendedBoundedStack", fn: AppendedBoundedStack[int, intSlice]},
+		} {
+			b.Run(fmt.Sprintf("total-%d/tail-%d/%s", totalSize, tailSize, f.name), func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range cs {
+							copy(cs[idx], in[idx])
+						}
+						b.StartTimer()
+					}
+					f.fn(cs[idx], uint(tailSize))
+				}
+			})
+		}
+	}
+}
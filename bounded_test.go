@@ -0,0 +1,108 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+func testAppendedBounded(t *testing.T, initial []byte, tailLenght uint, maxDisplacement int) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(fmt.Sprintf("%s (maxDisplacement: %d)", testName, maxDisplacement), func(t *testing.T) {
+		AppendedBounded(intSlice(s), tailLenght, maxDisplacement)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedBounded(t *testing.T) {
+	// the guarantee holds
+	testAppendedBounded(t, []byte{1, 3, 5, 7, 11, 13, 12, 14, 16, 15}, 4, 1)
+	testAppendedBounded(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 10, 14}, 4, 3)
+	// the guarantee is violated
+	testAppendedBounded(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 14, 12, 8, 1}, 4, 2)
+	testAppendedBounded(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 1, 8, 12, 14}, 4, 0)
+	// the window covers the whole slice
+	testAppendedBounded(t, []byte{5, 6, 7, 1, 3}, 2, 10)
+
+	t.Run("negative_maxDisplacement", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		AppendedBounded(intSlice{1, 2, 3}, 1, -1)
+	})
+}
+
+func FuzzAppendedBounded(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedBounded(t, initial, tailLenght, rand.Intn(len(initial)+1))
+	})
+}
+
+func BenchmarkAppendedBounded(b *testing.B) {
+	const (
+		totalSize       = 1024 * 1024
+		maxDisplacement = 64
+		csCount         = 20
+	)
+	for _, tailSize := range []int{16, 256, 1024} {
+		// simulate a timestamp stream: the points of the tail are slightly
+		// late, but never more than maxDisplacement positions
+		rng := rand.New(rand.NewSource(0))
+		in := make([][]int, csCount)
+		for idx := range in {
+			in[idx] = make([]int, totalSize)
+			s := in[idx]
+			for idx := range s {
+				s[idx] = idx * 10
+			}
+			for idx := totalSize - tailSize; idx < totalSize; idx++ {
+				s[idx] -= rng.Intn(maxDisplacement * 10)
+			}
+		}
+
+		cs := make([]intSlice, csCount)
+		for idx := range cs {
+			cs[idx] = make([]int, totalSize)
+		}
+
+		for _, f := range []struct {
+			name string
+			fn   func(intSlice, uint)
+		}{
+			{name: "Appended", fn: Appended[int, intSlice]},
+			{name: "AppendedBounded", fn: func(s intSlice, tailLength uint) {
+				AppendedBounded(s, tailLength, maxDisplacement)
+			}},
+		} {
+			b.Run(fmt.Sprintf("total-%d/tail-%d/%s", totalSize, tailSize, f.name), func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range cs {
+							copy(cs[idx], in[idx])
+						}
+						b.StartTimer()
+					}
+					f.fn(cs[idx], uint(tailSize))
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,23 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"reflect"
+)
+
+// AppendedWithBufClear is the same as AppendedWithBuf, but it zeroes
+// the buffer before returning, so a buffer reused across calls does not
+// keep references to the (already removed) elements and does not pin
+// the memory they point to.
+//
+// If E does not contain pointers, then the buffer is not zeroed
+// (there is nothing to leak).
+func AppendedWithBufClear[E any, S Interface[E]](s S, buf []E) {
+	AppendedWithBuf(s, buf)
+	if typeHasPointers(reflect.TypeOf((*E)(nil)).Elem()) {
+		clear(buf)
+	}
+}
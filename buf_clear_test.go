@@ -0,0 +1,77 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+type intPtrs []*int
+
+func (s intPtrs) Less(i, j int) bool {
+	return *s[i] < *s[j]
+}
+
+func TestAppendedWithBufClear(t *testing.T) {
+	const tailLength = 8
+	buf := make([]*int, tailLength)
+
+	collected := make(chan struct{}, 100)
+	func() {
+		s := make(intPtrs, 100)
+		for idx := range s {
+			v := new(int)
+			*v = len(s) - idx
+			if idx < len(s)-tailLength {
+				*v = idx * 2
+			}
+			runtime.SetFinalizer(v, func(*int) {
+				collected <- struct{}{}
+			})
+			s[idx] = v
+		}
+		AppendedWithBufClear(s, buf)
+		for idx := 1; idx < len(s); idx++ {
+			if *s[idx] < *s[idx-1] {
+				t.Fatalf("not sorted at %d", idx)
+			}
+		}
+	}()
+
+	for idx, v := range buf {
+		if v != nil {
+			t.Fatalf("buffer slot %d is not cleared", idx)
+		}
+	}
+
+	// nothing references the elements anymore, so all of them should be collectable
+	deadline := time.Now().Add(10 * time.Second)
+	for count := 0; count < 100; {
+		runtime.GC()
+		select {
+		case <-collected:
+			count++
+		case <-time.After(10 * time.Millisecond):
+			if time.Now().After(deadline) {
+				t.Fatalf("only %d of 100 elements are collected", count)
+			}
+		}
+	}
+	runtime.KeepAlive(buf)
+}
+
+func TestAppendedWithBufClearNoPointers(t *testing.T) {
+	s := intSlice{1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 21, 23, 25, 27, 4, 2}
+	buf := make([]int, 2)
+	AppendedWithBufClear(s, buf)
+	if !intsEqual(s, []int{1, 2, 3, 4, 5, 7, 9, 11, 13, 15, 17, 19, 21, 23, 25, 27}) {
+		t.Fatalf("unexpected result: %v", s)
+	}
+	if buf[0] == 0 && buf[1] == 0 {
+		t.Fatalf("the buffer of a pointer-free type is not expected to be cleared: %v", buf)
+	}
+}
@@ -0,0 +1,35 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+// AppendedBufFree is the same as AppendedWithBuf, but the buffer is
+// acquired from a `sync.Pool` (and released after the sort), so it could
+// be called from many goroutines (for example from a worker pool) without
+// threading a buffer through each of them.
+//
+// It shares the pool with AppendedAutoBuf, but unlike AppendedAutoBuf
+// the amount of buffers it releases to the pool is not bounded, instead
+// they are released by the garbage collector (as any other `sync.Pool`
+// entries). Buffers bigger than 1MiB are not pooled. Buffers of types
+// containing pointers are zeroed before being released (see
+// AppendedWithBufClear).
+//
+// It is safe for concurrent use.
+//
+// T: O(k*ln(n) + n)
+//
+// S: O(k) [if without `s`]
+func AppendedBufFree[E any, S Interface[E]](s S, tailLength uint) {
+	strategy := startAppended(s, tailLength, appendedPolicy{shouldUse: shouldUseAppendedWithBuf})
+	if strategy != StrategyGroupInsert {
+		// the buffer is not needed
+		finishAppended(s, tailLength, strategy)
+		return
+	}
+	pool := getAutoBufPool[E]()
+	buf := pool.get(int(tailLength))
+	groupInsertAppendSortWithBuf(s, buf)
+	pool.put(buf, false)
+}
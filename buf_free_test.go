@@ -0,0 +1,126 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func testAppendedBufFree(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		AppendedBufFree(intSlice(s), tailLenght)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedBufFree(t *testing.T) {
+	testAppendedBufFree(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedBufFree(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedBufFree(t, []byte{5, 4, 3, 2, 1}, 5)
+
+	t.Run("concurrent", func(t *testing.T) {
+		// is expected to be run with `-race` as well
+		var wg sync.WaitGroup
+		for worker := 0; worker < 64; worker++ {
+			wg.Add(1)
+			go func(seed int64) {
+				defer wg.Done()
+				rng := rand.New(rand.NewSource(seed))
+				for run := 0; run < 50; run++ {
+					s := make(intSlice, 300)
+					for idx := range s {
+						s[idx] = rng.Intn(100)
+					}
+					tailLength := rng.Intn(len(s) + 1)
+					stdsort.Ints(s[:len(s)-tailLength])
+					AppendedBufFree(s, uint(tailLength))
+					if !stdsort.IntsAreSorted(s) {
+						t.Error("not sorted")
+						return
+					}
+				}
+			}(int64(worker))
+		}
+		wg.Wait()
+	})
+
+	t.Run("cleared", func(t *testing.T) {
+		pool := getAutoBufPool[*int]()
+		buf := pool.get(3)
+		for idx := range buf {
+			buf[idx] = new(int)
+		}
+		pool.put(buf, false)
+		// sync.Pool may drop the buffer, but if not, then it should be cleared
+		buf = pool.get(3)
+		for idx, ptr := range buf {
+			if ptr != nil {
+				t.Fatalf("the buffer is not cleared at %d", idx)
+			}
+		}
+	})
+
+	t.Run("too_big", func(t *testing.T) {
+		pool := getAutoBufPool[bigElem]()
+		maxLength := 1 << (len(pool.classes) - 1)
+		big := pool.get(maxLength + 1)
+		pool.put(big, false)
+		if buf := pool.get(maxLength + 1); &buf[0] == &big[0] {
+			t.Fatal("a too big buffer is pooled")
+		}
+	})
+}
+
+func FuzzAppendedBufFree(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedBufFree(t, initial, tailLenght)
+	})
+}
+
+func BenchmarkAppendedBufFree(b *testing.B) {
+	const (
+		totalSize = 65536
+		tailSize  = 1024
+	)
+	in := make([]int, totalSize)
+	for idx := range in {
+		in[idx] = rand.Intn(totalSize)
+	}
+	stdsort.Ints(in[:totalSize-tailSize])
+
+	for _, f := range []struct {
+		name string
+		fn   func(intSlice, uint)
+	}{
+		{name: "AppendedWithBuf-make", fn: func(s intSlice, tailLength uint) {
+			AppendedWithBuf(s, make([]int, tailLength))
+		}},
+		{name: "AppendedAutoBuf", fn: AppendedAutoBuf[int, intSlice]},
+		{name: "AppendedBufFree", fn: AppendedBufFree[int, intSlice]},
+	} {
+		b.Run(fmt.Sprintf("total-%d/tail-%d/%s/parallel", totalSize, tailSize, f.name), func(b *testing.B) {
+			b.ReportAllocs()
+			b.RunParallel(func(pb *testing.PB) {
+				s := make(intSlice, totalSize)
+				for pb.Next() {
+					copy(s, in)
+					f.fn(s, tailSize)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// AppendedWithByteBuf is the same as AppendedWithBuf, but the buffer
+// is carved out of a raw byte arena. It allows to reuse the same memory
+// for sorting slices of different element types.
+//
+// The unsafe contract:
+//   - E must not contain pointers (including strings, slices, maps,
+//     interfaces, etc), because the garbage collector does not scan
+//     a []byte for pointers. The function panics otherwise.
+//   - The arena should be at least `tailLength*unsafe.Sizeof(E)` bytes
+//     long plus up to `unsafe.Alignof(E)-1` bytes for alignment; the
+//     function panics otherwise.
+//   - The content of the arena is overwritten and is unspecified
+//     after the call.
+func AppendedWithByteBuf[E any, S Interface[E]](s S, tailLength uint, arena []byte) {
+	checkTailLength(len(s), tailLength)
+	AppendedWithBuf(s, bytesAsSlice[E](arena, tailLength))
+}
+
+// bytesAsSlice reinterprets the beginning of the arena (aligned
+// appropriately for E) as a slice of E of length n.
+func bytesAsSlice[E any](arena []byte, n uint) []E {
+	var zero E
+	typ := reflect.TypeOf(&zero).Elem()
+	if typeHasPointers(typ) {
+		panic(fmt.Sprintf("type %v contains pointers and cannot be stored in a byte arena", typ))
+	}
+	if n == 0 {
+		return nil
+	}
+
+	size, align := unsafe.Sizeof(zero), unsafe.Alignof(zero)
+	if size == 0 {
+		return make([]E, n)
+	}
+	base := uintptr(unsafe.Pointer(unsafe.SliceData(arena)))
+	offset := (align - base%align) % align
+	if uintptr(n) > (^uintptr(0)-offset)/size || uintptr(len(arena)) < offset+size*uintptr(n) {
+		panic(fmt.Sprintf("the arena is too small: %d bytes, but %d elements of size %d (and alignment %d) are required", len(arena), n, size, align))
+	}
+	return unsafe.Slice((*E)(unsafe.Pointer(&arena[offset])), n)
+}
+
+// typeHasPointers returns true if values of the type may contain pointers.
+func typeHasPointers(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return false
+	case reflect.Array:
+		return typ.Len() > 0 && typeHasPointers(typ.Elem())
+	case reflect.Struct:
+		for idx := 0; idx < typ.NumField(); idx++ {
+			if typeHasPointers(typ.Field(idx).Type) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
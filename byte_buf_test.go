@@ -0,0 +1,91 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func testAppendedWithByteBuf(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		// misalign the arena on purpose
+		arena := make([]byte, uintptr(tailLenght)*unsafe.Sizeof(int(0))+unsafe.Alignof(int(0))+1)[1:]
+		AppendedWithByteBuf(intSlice(s), tailLenght, arena)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedWithByteBuf(t *testing.T) {
+	testAppendedWithByteBuf(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedWithByteBuf(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedWithByteBuf(t, []byte{49, 255, 127}, 2)
+	testAppendedWithByteBuf(t, []byte{65, 76, 173, 37, 67, 145}, 5)
+}
+
+func FuzzAppendedWithByteBuf(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedWithByteBuf(t, initial, tailLenght)
+	})
+}
+
+type point struct {
+	X, Y int16
+	Z    int8
+}
+
+type points []point
+
+func (s points) Less(i, j int) bool {
+	if s[i].X != s[j].X {
+		return s[i].X < s[j].X
+	}
+	return s[i].Y < s[j].Y
+}
+
+func TestAppendedWithByteBufStruct(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	s := make(points, 100)
+	for idx := range s {
+		s[idx] = point{X: int16(rng.Intn(20)), Y: int16(rng.Intn(20)), Z: int8(idx)}
+	}
+	stdsort.Slice(s[:90], s[:90].Less)
+
+	arena := make([]byte, 10*unsafe.Sizeof(point{})+unsafe.Alignof(point{}))
+	AppendedWithByteBuf(s, 10, arena)
+	if !stdsort.SliceIsSorted(s, s.Less) {
+		t.Fatalf("not sorted: %v", s)
+	}
+}
+
+func TestAppendedWithByteBufInvalid(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"too-small": func() {
+			AppendedWithByteBuf(intSlice{3, 1, 2}, 2, make([]byte, 2*unsafe.Sizeof(int(0))-1))
+		},
+		"pointers": func() {
+			AppendedWithByteBuf(stdsort.StringSlice{"b", "a"}, 1, make([]byte, 1024))
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected a panic")
+				}
+			}()
+			fn()
+		})
+	}
+}
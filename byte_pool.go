@@ -0,0 +1,76 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"math/bits"
+	"sync"
+	"unsafe"
+)
+
+// bytePoolMaxClass is the maximal size class of a BytePool: arenas
+// bigger than `1<<bytePoolMaxClass` bytes are allocated on each call.
+const bytePoolMaxClass = 20
+
+// BytePool is a pool of raw byte arenas, which are used as buffers
+// of AppendedPooledBytes. Since the arenas are untyped, a single pool
+// may back sorts of slices of different element types (which reduces
+// the total allocator pressure in a mixed workload).
+//
+// The arenas are grouped by power-of-two size classes; arenas bigger
+// than 1MiB are not kept.
+//
+// The zero value is an empty pool ready to use. It is safe for concurrent
+// use, but should not be copied after the first use.
+type BytePool struct {
+	classes [bytePoolMaxClass + 1]sync.Pool
+}
+
+// get returns an arena of at least size bytes.
+func (p *BytePool) get(size uintptr) *[]byte {
+	class := bits.Len(uint(size - 1))
+	if size == 0 {
+		class = 0
+	}
+	if class >= len(p.classes) {
+		arena := make([]byte, size)
+		return &arena
+	}
+	if arena, ok := p.classes[class].Get().(*[]byte); ok {
+		return arena
+	}
+	arena := make([]byte, 1<<class)
+	return &arena
+}
+
+// put returns the arena to the pool (if it is not too big).
+func (p *BytePool) put(arena *[]byte) {
+	class := bits.Len(uint(len(*arena) - 1))
+	if class >= len(p.classes) || len(*arena) != 1<<class {
+		return
+	}
+	p.classes[class].Put(arena)
+}
+
+// AppendedPooledBytes is the same as AppendedWithByteBuf, but the arena
+// is taken from the pool (and returned back after the sort).
+//
+// The same restriction applies: E must not contain pointers (including
+// strings, slices, maps, interfaces, etc), the function panics otherwise.
+// The pool must not be nil.
+//
+// T: O(k*ln(n) + n)
+//
+// S: O(k) [if without `s`]
+func AppendedPooledBytes[E any, S Interface[E]](s S, tailLength uint, pool *BytePool) {
+	checkTailLength(len(s), tailLength)
+	if tailLength == 0 {
+		return
+	}
+	var zero E
+	arena := pool.get(uintptr(tailLength)*unsafe.Sizeof(zero) + unsafe.Alignof(zero) - 1)
+	AppendedWithByteBuf(s, tailLength, *arena)
+	pool.put(arena)
+}
@@ -0,0 +1,166 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func testAppendedPooledBytes(t *testing.T, initial []byte, tailLenght uint, pool *BytePool) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		AppendedPooledBytes(intSlice(s), tailLenght, pool)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+// smallKeys is a slice of a small struct without pointers.
+type smallKey struct {
+	A int16
+	B uint8
+}
+
+type smallKeys []smallKey
+
+func (s smallKeys) Less(i, j int) bool {
+	if s[i].A != s[j].A {
+		return s[i].A < s[j].A
+	}
+	return s[i].B < s[j].B
+}
+
+func TestAppendedPooledBytes(t *testing.T) {
+	var pool BytePool
+	testAppendedPooledBytes(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4, &pool)
+	testAppendedPooledBytes(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4, &pool)
+	testAppendedPooledBytes(t, []byte{5, 4, 3, 2, 1}, 5, &pool)
+
+	t.Run("mixed_types", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(0))
+		for run := 0; run < 100; run++ {
+			floats := make(stdsort.Float64Slice, 1000)
+			keys := make(smallKeys, 1000)
+			for idx := range floats {
+				floats[idx] = rng.Float64()
+				keys[idx] = smallKey{A: int16(rng.Intn(100)), B: uint8(rng.Intn(256))}
+			}
+			tailLength := rng.Intn(100)
+			stdsort.Sort(floats[:len(floats)-tailLength])
+			stdsort.Sort(stdInterface[smallKey, smallKeys](keys[:len(keys)-tailLength]))
+
+			AppendedPooledBytes(floats, uint(tailLength), &pool)
+			AppendedPooledBytes(keys, uint(tailLength), &pool)
+			if !stdsort.IsSorted(floats) || !stdsort.IsSorted(stdInterface[smallKey, smallKeys](keys)) {
+				t.Fatal("not sorted")
+			}
+		}
+	})
+
+	t.Run("pointers", func(t *testing.T) {
+		expectPanic(t, "contains pointers", func() {
+			AppendedPooledBytes(stdsort.StringSlice{"b", "a"}, 1, &pool)
+		})
+	})
+
+	t.Run("allocs", func(t *testing.T) {
+		s := make(intSlice, 1000)
+		allocs := testing.AllocsPerRun(100, func() {
+			for idx := range s {
+				s[idx] = idx
+			}
+			s[len(s)-1] = -1
+			AppendedPooledBytes(s, 10, &pool)
+		})
+		if allocs > 0 {
+			t.Fatalf("unexpected allocations: %v", allocs)
+		}
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for worker := 0; worker < 8; worker++ {
+			wg.Add(1)
+			go func(seed int64) {
+				defer wg.Done()
+				rng := rand.New(rand.NewSource(seed))
+				for run := 0; run < 100; run++ {
+					s := make(intSlice, 200)
+					for idx := range s {
+						s[idx] = rng.Intn(100)
+					}
+					tailLength := rng.Intn(50)
+					stdsort.Ints(s[:len(s)-tailLength])
+					AppendedPooledBytes(s, uint(tailLength), &pool)
+					if !stdsort.IntsAreSorted(s) {
+						t.Error("not sorted")
+						return
+					}
+				}
+			}(int64(worker))
+		}
+		wg.Wait()
+	})
+}
+
+func FuzzAppendedPooledBytes(f *testing.F) {
+	var pool BytePool
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedPooledBytes(t, initial, tailLenght, &pool)
+	})
+}
+
+func BenchmarkAppendedPooledBytes(b *testing.B) {
+	const (
+		totalSize = 65536
+		tailSize  = 1024
+	)
+	rng := rand.New(rand.NewSource(0))
+	ints := make(intSlice, totalSize)
+	floats := make(stdsort.Float64Slice, totalSize)
+	for idx := range ints {
+		ints[idx] = rng.Intn(totalSize)
+		floats[idx] = rng.Float64()
+	}
+	stdsort.Ints(ints[:totalSize-tailSize])
+	stdsort.Float64s(floats[:totalSize-tailSize])
+	intsCopy := make(intSlice, totalSize)
+	floatsCopy := make(stdsort.Float64Slice, totalSize)
+
+	var pool BytePool
+	for _, f := range []struct {
+		name string
+		fn   func()
+	}{
+		{name: "AppendedWithBuf", fn: func() {
+			AppendedWithBuf(intsCopy, make([]int, tailSize))
+			AppendedWithBuf(floatsCopy, make([]float64, tailSize))
+		}},
+		{name: "AppendedPooledBytes", fn: func() {
+			AppendedPooledBytes(intsCopy, tailSize, &pool)
+			AppendedPooledBytes(floatsCopy, tailSize, &pool)
+		}},
+	} {
+		b.Run(f.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				copy(intsCopy, ints)
+				copy(floatsCopy, floats)
+				b.StartTimer()
+				f.fn()
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+// IsAppendedSortable returns true if the slice satisfies the precondition
+// of Appended (and other functions of this package accepting a tail length):
+// the first `len(s)-tailLength` elements are already sorted.
+//
+// It returns false if tailLength is greater than the length of the slice.
+//
+// T: O(n-k)
+//
+// S: O(1)
+func IsAppendedSortable[E any, S Interface[E]](s S, tailLength uint) bool {
+	if tailLength > uint(len(s)) {
+		return false
+	}
+	prefixLength := len(s) - int(tailLength)
+	return SortedPrefixLen(s[:prefixLength]) == prefixLength
+}
+
+// SortedPrefixLen returns the length of the longest already sorted prefix
+// of the slice. For example, if the slice was sorted and then some elements
+// were appended, then `Appended(s, uint(len(s)-SortedPrefixLen(s)))` sorts
+// the slice.
+//
+// T: O(n) [it stops at the first inversion]
+//
+// S: O(1)
+func SortedPrefixLen[E any, S Interface[E]](s S) int {
+	for idx := 1; idx < len(s); idx++ {
+		if s.Less(idx, idx-1) {
+			return idx
+		}
+	}
+	return len(s)
+}
+
+// SortedSuffixLen returns the length of the longest already sorted suffix
+// of the slice.
+//
+// It is useful to characterize the input before choosing a strategy:
+// if `SortedPrefixLen(s)+SortedSuffixLen(s) >= len(s)`, then the slice
+// consists of two sorted runs (which may be merged, see MergeRuns).
+//
+// T: O(n) [it stops at the last inversion]
+//
+// S: O(1)
+func SortedSuffixLen[E any, S Interface[E]](s S) int {
+	for idx := len(s) - 1; idx > 0; idx-- {
+		if s.Less(idx, idx-1) {
+			return len(s) - idx
+		}
+	}
+	return len(s)
+}
@@ -0,0 +1,83 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestIsAppendedSortable(t *testing.T) {
+	for _, testCase := range []struct {
+		s          intSlice
+		tailLength uint
+		expected   bool
+	}{
+		{nil, 0, true},
+		{nil, 1, false},
+		{intSlice{1, 2, 3}, 0, true},
+		{intSlice{1, 2, 3}, 4, false},
+		{intSlice{3, 2, 1}, 3, true},
+		{intSlice{3, 2, 1}, 2, true},
+		{intSlice{3, 2, 1}, 1, false},
+		{intSlice{1, 1, 2, 5, 0, 9}, 2, true},
+		{intSlice{1, 3, 2, 5, 0, 9}, 2, false},
+	} {
+		t.Run(fmt.Sprintf("%v (tailLength: %d)", testCase.s, testCase.tailLength), func(t *testing.T) {
+			if result := IsAppendedSortable(testCase.s, testCase.tailLength); result != testCase.expected {
+				t.Fatalf("%v != %v", result, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestSortedPrefixLen(t *testing.T) {
+	for _, testCase := range []struct {
+		s        intSlice
+		expected int
+	}{
+		{nil, 0},
+		{intSlice{1}, 1},
+		{intSlice{1, 2, 3}, 3},
+		{intSlice{1, 1, 1}, 3},
+		{intSlice{3, 2, 1}, 1},
+		{intSlice{1, 2, 5, 3, 4}, 3},
+		{intSlice{1, 1, 2, 5, 0, 9}, 4},
+	} {
+		t.Run(fmt.Sprint(testCase.s), func(t *testing.T) {
+			if result := SortedPrefixLen(testCase.s); result != testCase.expected {
+				t.Fatalf("%d != %d", result, testCase.expected)
+			}
+			if !IsAppendedSortable(testCase.s, uint(len(testCase.s)-testCase.expected)) {
+				t.Fatalf("the prefix is not sorted")
+			}
+		})
+	}
+}
+
+func TestSortedSuffixLen(t *testing.T) {
+	for _, testCase := range []struct {
+		s                    intSlice
+		expectedPrefixLength int
+		expectedSuffixLength int
+	}{
+		{nil, 0, 0},
+		{intSlice{1}, 1, 1},
+		{intSlice{1, 2, 3, 4}, 4, 4},
+		{intSlice{4, 3, 2, 1}, 1, 1},
+		{intSlice{1, 2, 5, 3, 4}, 3, 2},
+		{intSlice{2, 1, 3, 4, 5}, 1, 4},
+		{intSlice{1, 2, 3, 4, 0}, 4, 1},
+	} {
+		t.Run(fmt.Sprint(testCase.s), func(t *testing.T) {
+			if result := SortedSuffixLen(testCase.s); result != testCase.expectedSuffixLength {
+				t.Fatalf("suffix: %d != %d", result, testCase.expectedSuffixLength)
+			}
+			if result := SortedPrefixLen(testCase.s); result != testCase.expectedPrefixLength {
+				t.Fatalf("prefix: %d != %d", result, testCase.expectedPrefixLength)
+			}
+		})
+	}
+}
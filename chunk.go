@@ -0,0 +1,56 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+
+	"github.com/go-ng/sort"
+)
+
+// ChunkSortMerge sorts the slice in chunks of length chunkSize (the last
+// chunk may be shorter) and then merges the chunks pairwise (like
+// MergeRuns does for a few runs). If chunkSize is chosen to make a chunk fit into a CPU cache,
+// then it might be faster than a single `sort.Sort` for huge slices.
+//
+// If the last chunk is small enough, it is inserted into the merged
+// chunks the same way as in Appended instead of being merged.
+//
+// The result is not stable.
+//
+// T: O(n*ln(c) + n*ln(n/c)), where `c` is chunkSize
+//
+// S: O(n)
+func ChunkSortMerge[E any, S Interface[E]](s S, chunkSize int) {
+	if chunkSize <= 0 {
+		panic(fmt.Sprintf("chunkSize (%d) should be positive", chunkSize))
+	}
+	if len(s) <= chunkSize {
+		sort.Sort(s)
+		return
+	}
+
+	remainder := len(s) % chunkSize
+	fullLength := len(s) - remainder
+	bounds := make([]int, 1, fullLength/chunkSize+2)
+	for start := 0; start < fullLength; start += chunkSize {
+		sort.Sort(s[start : start+chunkSize])
+		bounds = append(bounds, start+chunkSize)
+	}
+
+	if remainder > 0 && shouldUseAppended(uint(len(s)), uint(remainder)) {
+		if len(bounds) > 2 {
+			mergeRunsPairwise(s[:fullLength], bounds, nil)
+		}
+		Appended(s, uint(remainder))
+		return
+	}
+
+	if remainder > 0 {
+		sort.Sort(s[fullLength:])
+		bounds = append(bounds, len(s))
+	}
+	mergeRunsPairwise(s, bounds, nil)
+}
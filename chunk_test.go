@@ -0,0 +1,91 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"testing"
+
+	"github.com/go-ng/sort"
+)
+
+func testChunkSortMerge(t *testing.T, initial []byte, chunkSize int) {
+	s := make([]int, len(initial))
+	for idx, v := range initial {
+		s[idx] = int(v)
+	}
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(fmt.Sprintf("total-%d/chunk-%d", len(s), chunkSize), func(t *testing.T) {
+		ChunkSortMerge(intSlice(s), chunkSize)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v", c, s)
+		}
+	})
+}
+
+func TestChunkSortMerge(t *testing.T) {
+	testChunkSortMerge(t, []byte{}, 1)
+	testChunkSortMerge(t, []byte{3, 2, 1}, 1)
+	testChunkSortMerge(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 3)
+	testChunkSortMerge(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 100)
+
+	rng := rand.New(rand.NewSource(0))
+	initial := make([]byte, 10000)
+	rng.Read(initial)
+	for _, chunkSize := range []int{7, 999, 1000, 3333} {
+		// 10000 % 999 == 10, so the last chunk is inserted Appended-style
+		testChunkSortMerge(t, initial, chunkSize)
+	}
+
+	t.Run("non-positive_chunkSize", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		ChunkSortMerge(intSlice{2, 1}, 0)
+	})
+}
+
+func FuzzChunkSortMerge(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		chunkSize := rand.Intn(len(initial)+1) + 1
+		testChunkSortMerge(t, initial, chunkSize)
+	})
+}
+
+func BenchmarkChunkSortMerge(b *testing.B) {
+	const totalSize = 1 << 24
+	rng := rand.New(rand.NewSource(0))
+	in := make([]int, totalSize)
+	for idx := range in {
+		in[idx] = rng.Int()
+	}
+	s := make(intSlice, totalSize)
+
+	for _, f := range []struct {
+		name string
+		fn   func(intSlice)
+	}{
+		{name: "sort.Sort", fn: sort.Sort[int, intSlice]},
+		{name: "ChunkSortMerge-32768", fn: func(s intSlice) { ChunkSortMerge(s, 32768) }},
+		{name: "ChunkSortMerge-262144", fn: func(s intSlice) { ChunkSortMerge(s, 262144) }},
+	} {
+		b.Run(fmt.Sprintf("total-%d/%s", totalSize, f.name), func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				copy(s, in)
+				b.StartTimer()
+				f.fn(s)
+			}
+		})
+	}
+}
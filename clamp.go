@@ -0,0 +1,38 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// AppendedClamp is the same as AppendedOrdered, but each element
+// of the tail is clamped into the range `[lo, hi]` before sorting.
+//
+// The prefix is assumed to be already clamped (and sorted): it is not
+// traversed. Do not confuse with AppendedClamped, which clamps tailLength.
+//
+// It panics if lo is greater than hi.
+//
+// T: the same as Appended
+//
+// S: O(1) [if without `s`]
+func AppendedClamp[E cmp.Ordered](s []E, tailLength uint, lo, hi E) {
+	if cmp.Less(hi, lo) {
+		panic(fmt.Sprintf("lo (%v) cannot be greater than hi (%v)", lo, hi))
+	}
+	checkTailLength(len(s), tailLength)
+	tail := s[uint(len(s))-tailLength:]
+	for idx, v := range tail {
+		switch {
+		case cmp.Less(v, lo):
+			tail[idx] = lo
+		case cmp.Less(hi, v):
+			tail[idx] = hi
+		}
+	}
+	AppendedOrdered(s, tailLength)
+}
@@ -0,0 +1,59 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"math"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+func testAppendedClamp(t *testing.T, initial []byte, tailLenght uint, lo, hi int) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	for idx := range s[:len(s)-int(tailLenght)] {
+		s[idx] = min(max(s[idx], lo), hi)
+	}
+	c := make([]int, len(s))
+	for idx, v := range s {
+		c[idx] = min(max(v, lo), hi)
+	}
+	t.Run(testName, func(t *testing.T) {
+		AppendedClamp(s, tailLenght, lo, hi)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedClamp(t *testing.T) {
+	testAppendedClamp(t, []byte{}, 0, 0, 0)
+	testAppendedClamp(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4, 5, 10)
+	testAppendedClamp(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4, 3, 11)
+	testAppendedClamp(t, []byte{5, 4, 3, 2, 1}, 5, 2, 2)
+
+	s := []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, -5, 100, math.Inf(1), 7.5}
+	AppendedClamp(s, 4, 0, 16)
+	expected := []float64{0, 0, 1, 2, 3, 4, 5, 6, 7, 7.5, 8, 9, 10, 11, 12, 13, 14, 15, 16, 16, 16}
+	for idx := range expected {
+		if s[idx] != expected[idx] {
+			t.Fatalf("%v != %v", s, expected)
+		}
+	}
+
+	expectPanic(t, "cannot be greater than hi", func() {
+		AppendedClamp([]int{1}, 1, 2, 1)
+	})
+}
+
+func FuzzAppendedClamp(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		lo := rand.Intn(256)
+		testAppendedClamp(t, initial, tailLenght, lo, lo+rand.Intn(256))
+	})
+}
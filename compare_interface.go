@@ -0,0 +1,38 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+// CompareInterface adapts a slice and a three-way comparison function
+// (like `cmp.Compare`) to the standard `sort.Interface`. Less is derived
+// as `cmp(a, b) < 0`.
+//
+// Interface may be satisfied only by slice types (which cannot carry
+// a comparison function), so pass a CompareInterface to AppendedIndexed
+// (or use AppendedFunc, which accepts a comparison function directly).
+type CompareInterface[E any] struct {
+	s   []E
+	cmp func(a, b E) int
+}
+
+// NewCompareInterface returns a CompareInterface for the slice s and
+// the comparison function cmp.
+func NewCompareInterface[E any](s []E, cmp func(a, b E) int) CompareInterface[E] {
+	return CompareInterface[E]{s: s, cmp: cmp}
+}
+
+// Len implements `sort.Interface`.
+func (c CompareInterface[E]) Len() int {
+	return len(c.s)
+}
+
+// Less implements `sort.Interface`.
+func (c CompareInterface[E]) Less(i, j int) bool {
+	return c.cmp(c.s[i], c.s[j]) < 0
+}
+
+// Swap implements `sort.Interface`.
+func (c CompareInterface[E]) Swap(i, j int) {
+	c.s[i], c.s[j] = c.s[j], c.s[i]
+}
@@ -0,0 +1,39 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"cmp"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+func testCompareInterface(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		AppendedIndexed(NewCompareInterface(s, cmp.Compare[int]), tailLenght)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestCompareInterface(t *testing.T) {
+	testCompareInterface(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testCompareInterface(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testCompareInterface(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 14, 12, 8, 1}, 4)
+}
+
+func FuzzCompareInterface(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testCompareInterface(t, initial, tailLenght)
+	})
+}
@@ -0,0 +1,133 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"github.com/go-ng/slices"
+	"github.com/go-ng/sort"
+)
+
+// Counts contains the amounts of calls of the methods of
+// a CountingInterface, or the operations performed by AppendedCounting.
+type Counts struct {
+	Len  uint64
+	Less uint64
+	Swap uint64
+
+	// Moves is the amount of elements moved by rotations and reversals
+	// (only AppendedCounting counts it).
+	Moves uint64
+}
+
+// AppendedCounting is the same as Appended (it runs exactly the same
+// code path, including the sorting of the tail or the fallback sort), but
+// it also returns the amounts of the performed operations. It is useful to
+// profile expensive comparators.
+//
+// Less is the amount of all the calls of Less; Swap and Moves are
+// the amounts of the swaps and of the elements moved by rotations and
+// reversals while inserting the tail (the swaps performed inside
+// the sorting are not counted). Len is always zero.
+//
+// T: the same as Appended
+//
+// S: O(1) [if without `s`]
+func AppendedCounting[E any, S Interface[E]](s S, tailLength uint) Counts {
+	var counts Counts
+	appendedSeq(countingSeq[E, S]{s: s, counts: &counts}, tailLength)
+	return counts
+}
+
+// countingSeq is the sequence of a slice, which counts the operations
+// into counts. Its Sort and SortDescending are the same as of
+// stdInterface, but the comparisons are counted.
+type countingSeq[E any, S Interface[E]] struct {
+	s      S
+	counts *Counts
+}
+
+func (q countingSeq[E, S]) Len() int {
+	return len(q.s)
+}
+
+func (q countingSeq[E, S]) Less(i, j int) bool {
+	q.counts.Less++
+	return q.s.Less(i, j)
+}
+
+func (q countingSeq[E, S]) Swap(i, j int) {
+	q.counts.Swap++
+	q.s[i], q.s[j] = q.s[j], q.s[i]
+}
+
+func (q countingSeq[E, S]) Rotate(a, b, shift int) {
+	q.counts.Moves += uint64(b - a)
+	slices.Rotate(q.s[a:b], shift)
+}
+
+func (q countingSeq[E, S]) Reverse(a, b int) {
+	q.counts.Moves += uint64(b - a)
+	slices.Reverse(q.s[a:b])
+}
+
+func (q countingSeq[E, S]) Sort(a, b int) {
+	// sort.Slice is the same algorithm as sort.Sort (used by
+	// stdInterface), thus the comparisons are the same.
+	sub := q.s[a:b]
+	sort.Slice(sub, func(i, j int) bool {
+		q.counts.Less++
+		return sub.Less(i, j)
+	})
+}
+
+func (q countingSeq[E, S]) SortDescending(a, b int) {
+	sub := q.s[a:b]
+	sort.Slice(sub, func(i, j int) bool {
+		q.counts.Less++
+		return sub.Less(j, i)
+	})
+}
+
+// CountingInterface wraps an Interface and counts the calls of Len, Less
+// and Swap.
+//
+// Interface may be satisfied only by slice types, so CountingInterface
+// implements the standard `sort.Interface` instead: it is useful with
+// the standard `sort` package or AppendedIndexed (which makes the same
+// decisions as Appended, but sorts with the standard `sort` package and
+// moves the elements only through Swap). To count the operations of
+// Appended itself use AppendedCounting.
+type CountingInterface[E any, S Interface[E]] struct {
+	s      S
+	counts *Counts
+}
+
+// NewCounting returns a CountingInterface wrapping s and the counters
+// it increments.
+func NewCounting[E any, S Interface[E]](s S) (*CountingInterface[E, S], *Counts) {
+	counts := &Counts{}
+	return &CountingInterface[E, S]{
+		s:      s,
+		counts: counts,
+	}, counts
+}
+
+// Len implements `sort.Interface`.
+func (c *CountingInterface[E, S]) Len() int {
+	c.counts.Len++
+	return len(c.s)
+}
+
+// Less implements `sort.Interface`.
+func (c *CountingInterface[E, S]) Less(i, j int) bool {
+	c.counts.Less++
+	return c.s.Less(i, j)
+}
+
+// Swap implements `sort.Interface`.
+func (c *CountingInterface[E, S]) Swap(i, j int) {
+	c.counts.Swap++
+	c.s[i], c.s[j] = c.s[j], c.s[i]
+}
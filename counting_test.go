@@ -0,0 +1,122 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"math/rand"
+	stdsort "sort"
+	"testing"
+)
+
+func TestCountingInterface(t *testing.T) {
+	t.Run("hand-computed", func(t *testing.T) {
+		c, counts := NewCounting(intSlice{1, 2, 3, 4, 5})
+
+		// IsSorted calls Len once and Less for each pair of neighbours.
+		if !stdsort.IsSorted(c) {
+			t.Fatal("expected to be sorted")
+		}
+		if *counts != (Counts{Len: 1, Less: 4}) {
+			t.Fatalf("unexpected counts: %+v", *counts)
+		}
+
+		c.Swap(0, 4)
+		c.Swap(1, 3)
+		if *counts != (Counts{Len: 1, Less: 4, Swap: 2}) {
+			t.Fatalf("unexpected counts: %+v", *counts)
+		}
+		if !intsEqual(c.s, []int{5, 4, 3, 2, 1}) {
+			t.Fatalf("unexpected slice: %v", c.s)
+		}
+
+		// nothing to do with an empty tail (except reading the length)
+		AppendedIndexed(c, 0)
+		if *counts != (Counts{Len: 2, Less: 4, Swap: 2}) {
+			t.Fatalf("unexpected counts: %+v", *counts)
+		}
+	})
+
+	t.Run("AppendedIndexed", func(t *testing.T) {
+		s := intSlice{1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 21, 23, 25, 27, 29, 31, 10, 2}
+		c, counts := NewCounting(s)
+		AppendedIndexed(c, 2)
+		if !stdsort.IntsAreSorted(s) {
+			t.Fatalf("not sorted: %v", s)
+		}
+		if counts.Len == 0 || counts.Less == 0 || counts.Swap == 0 {
+			t.Fatalf("unexpected counts: %+v", *counts)
+		}
+	})
+}
+
+func TestAppendedCounting(t *testing.T) {
+	t.Run("hand-computed", func(t *testing.T) {
+		s := make(intSlice, 20)
+		for idx := range s {
+			s[idx] = idx * 2
+		}
+		s[19] = 1
+
+		// 1 comparison to check if the tail is after the prefix, 5 for
+		// the binary search in 19 elements; then the tail is moved
+		// by a rotation of s[2:20], a swap and a rotation of s[1:3].
+		counts := AppendedCounting(s, 1)
+		if counts != (Counts{Less: 6, Swap: 1, Moves: 20}) {
+			t.Fatalf("unexpected counts: %+v", counts)
+		}
+		if !stdsort.IntsAreSorted(s) {
+			t.Fatalf("not sorted: %v", s)
+		}
+	})
+
+	t.Run("the same as Appended", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(0))
+		for _, totalSize := range []int{10, 100, 1000, 10000} {
+			for _, tailLength := range []uint{0, 1, 10, 100, 1000} {
+				if tailLength > uint(totalSize) {
+					continue
+				}
+				values := make([]int, totalSize)
+				for idx := range values {
+					values[idx] = rng.Intn(totalSize)
+				}
+				stdsort.Ints(values[:uint(totalSize)-tailLength])
+
+				s, lessCalls := newCountedInts(values)
+				Appended(s, tailLength)
+				counts := AppendedCounting(intSlice(values), tailLength)
+				if counts.Less != uint64(*lessCalls) {
+					t.Fatalf("%d/%d: %d != %d", totalSize, tailLength, counts.Less, *lessCalls)
+				}
+				for idx := range values {
+					if s[idx].v != values[idx] {
+						t.Fatalf("%d/%d: different results at %d", totalSize, tailLength, idx)
+					}
+				}
+			}
+		}
+	})
+}
+
+type countedInt struct {
+	v     int
+	count *int
+}
+
+type countedInts []countedInt
+
+func (s countedInts) Less(i, j int) bool {
+	*s[i].count++
+	return s[i].v < s[j].v
+}
+
+func newCountedInts(values []int) (countedInts, *int) {
+	count := new(int)
+	s := make(countedInts, len(values))
+	for idx, v := range values {
+		s[idx] = countedInt{v: v, count: count}
+	}
+	return s, count
+}
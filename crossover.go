@@ -0,0 +1,34 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	stdsort "sort"
+)
+
+// CrossoverTailLength returns the largest tail length for which Appended
+// uses the optimization (instead of a full sort) for a slice
+// of length totalSize. It is useful to decide when to flush pending
+// elements: once the amount of the unsorted elements approaches
+// the crossover, there is no benefit in postponing the sort.
+//
+// Returns 0 if the optimization is never used for the given totalSize
+// (including if totalSize is less than MinAppendedSize).
+//
+// T: O(ln(n))
+func CrossoverTailLength(totalSize uint) uint {
+	if isBelowMinAppendedSize(totalSize) {
+		return 0
+	}
+	// shouldUseAppended is monotonic in tailSize (once it returns false
+	// for some tailSize, it returns false for any bigger tailSize).
+	n := stdsort.Search(int(totalSize)+1, func(tailSize int) bool {
+		return tailSize > 0 && !shouldUseAppended(totalSize, uint(tailSize))
+	})
+	if n == 0 {
+		return 0
+	}
+	return uint(n - 1)
+}
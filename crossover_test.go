@@ -0,0 +1,145 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"testing"
+	"time"
+
+	"github.com/go-ng/sort"
+)
+
+func TestCrossoverTailLength(t *testing.T) {
+	for _, testCase := range []struct {
+		totalSize uint
+		expected  uint
+	}{
+		{totalSize: 0, expected: 0},
+		{totalSize: 1, expected: 0},
+		{totalSize: 4, expected: 0},
+		{totalSize: 5, expected: 0},
+		{totalSize: 16, expected: 3},
+		{totalSize: 511, expected: 127},
+		{totalSize: 512, expected: 181},
+		{totalSize: 65536, expected: 2047},
+		{totalSize: 1048576, expected: 8191},
+	} {
+		t.Run(fmt.Sprint(testCase.totalSize), func(t *testing.T) {
+			r := CrossoverTailLength(testCase.totalSize)
+			if r != testCase.expected {
+				t.Fatalf("%d != %d", r, testCase.expected)
+			}
+		})
+	}
+
+	t.Run("consistency", func(t *testing.T) {
+		var prev uint
+		for totalSize := uint(1); totalSize < 1<<20; totalSize = totalSize*9/8 + 1 {
+			crossover := CrossoverTailLength(totalSize)
+			if crossover < prev {
+				t.Fatalf("not monotonic at %d: %d < %d", totalSize, crossover, prev)
+			}
+			prev = crossover
+			if totalSize < uint(MinAppendedSize) {
+				if crossover != 0 {
+					t.Fatalf("the optimization is used below MinAppendedSize (totalSize: %d)", totalSize)
+				}
+				continue
+			}
+			if crossover > 0 && !shouldUseAppended(totalSize, crossover) {
+				t.Fatalf("the optimization is not used at the crossover %d (totalSize: %d)", crossover, totalSize)
+			}
+			if shouldUseAppended(totalSize, crossover+1) {
+				t.Fatalf("the optimization is used after the crossover %d (totalSize: %d)", crossover, totalSize)
+			}
+		}
+	})
+}
+
+// measureSort returns the time per operation of fn applied to copies of
+// slices of length totalSize with sorted prefixes and random tails of
+// length tailSize.
+func measureSort(totalSize, tailSize int, fn func(intSlice)) time.Duration {
+	const csCount = 16
+	rng := rand.New(rand.NewSource(0))
+	in := make([][]int, csCount)
+	for idx := range in {
+		in[idx] = make([]int, totalSize)
+		s := in[idx]
+		for idx := range s {
+			s[idx] = rng.Intn(totalSize)
+		}
+		stdsort.Ints(s[:totalSize-tailSize])
+	}
+	cs := make([]intSlice, csCount)
+	for idx := range cs {
+		cs[idx] = make([]int, totalSize)
+	}
+
+	r := testing.Benchmark(func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			idx := i % csCount
+			if idx == 0 {
+				b.StopTimer()
+				for idx := range cs {
+					copy(cs[idx], in[idx])
+				}
+				b.StartTimer()
+			}
+			fn(cs[idx])
+		}
+	})
+	return time.Duration(r.NsPerOp())
+}
+
+func TestCrossoverTailLengthEmpirical(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this test takes a lot of time")
+	}
+
+	// the measurements are noisy, so only a gross deviation is reported
+	const tolerance = 4
+
+	for _, totalSize := range []int{4096, 65536} {
+		totalSize := totalSize
+		t.Run(fmt.Sprint(totalSize), func(t *testing.T) {
+			predicted := int(CrossoverTailLength(uint(totalSize)))
+
+			// the time of a full sort barely depends on the tail length
+			full := measureSort(totalSize, predicted, func(s intSlice) {
+				sort.Sort(s)
+			})
+			t.Logf("sort: %v", full)
+
+			// the empirical crossover is between the largest checked tail
+			// length for which the group-insert is still faster than a full
+			// sort and the smallest one for which it is slower. The search
+			// is not limited by the tolerance, so a crossover far above
+			// the predicted one is detected as well.
+			lastFaster, firstSlower := 0, 0
+			for tailSize := predicted/tolerance/2 + 1; tailSize <= totalSize; tailSize *= 2 {
+				appended := measureSort(totalSize, tailSize, func(s intSlice) {
+					groupInsertAppendSort(s, uint(tailSize))
+				})
+				t.Logf("tail-%d: appended: %v", tailSize, appended)
+				if appended > full {
+					firstSlower = tailSize
+					break
+				}
+				lastFaster = tailSize
+			}
+
+			if firstSlower == 0 {
+				t.Fatalf("the group-insert is faster than a full sort for any tail length (the predicted crossover is %d)", predicted)
+			}
+			if firstSlower*tolerance < predicted || lastFaster > predicted*tolerance {
+				t.Fatalf("the empirical crossover (%d..%d) is too far from the predicted one %d", lastFaster, firstSlower, predicted)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+// AppendedDescWithBuf is the same as AppendedWithBuf, but for slices
+// sorted in descending order: it assumes the prefix is already sorted
+// in descending order, and sorts the whole slice in descending order.
+//
+// It is useful for priority-queue-like slices.
+//
+// The buffer length should be exactly the same as the length
+// of the unsorted tail.
+//
+// T: O(k*ln(n) + n)
+//
+// S: O(k) [if without `s`]
+func AppendedDescWithBuf[E any, S Interface[E]](s S, buf []E) {
+	AppendedWithBuf(descending[E, S](s), buf)
+}
+
+// descending is a view of an Interface with the inverted order.
+type descending[E any, S Interface[E]] []E
+
+func (s descending[E, S]) Less(i, j int) bool {
+	return S(s).Less(j, i)
+}
+
+// SortDescendingRun sorts the slice in descending order (according to
+// Less). It is the intermediate step of Appended: the unsorted tail is
+// sorted in descending order before it is merged into the prefix (see
+// groupInsertAppendSort for the reasons).
+//
+// The sort is not stable; an already descending slice is left untouched.
+//
+// T: O(n*ln(n)) [O(n) if already descending]
+//
+// S: O(ln(n))
+func SortDescendingRun[E any, S Interface[E]](s S) {
+	sortTailDescending(s)
+}
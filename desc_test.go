@@ -0,0 +1,83 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdslices "slices"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+func testAppendedDescWithBuf(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	prefix := s[:len(s)-int(tailLenght)]
+	stdsort.Sort(stdsort.Reverse(stdsort.IntSlice(prefix)))
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		AppendedDescWithBuf(intSlice(s), make([]int, tailLenght))
+		stdsort.Sort(stdsort.Reverse(stdsort.IntSlice(c)))
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedDescWithBuf(t *testing.T) {
+	testAppendedDescWithBuf(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedDescWithBuf(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedDescWithBuf(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 14, 12, 8, 1}, 4)
+	testAppendedDescWithBuf(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 1, 8, 12, 14}, 4)
+
+	t.Run("buffer_longer_than_slice", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		AppendedDescWithBuf(intSlice{3, 2, 1}, make([]int, 4))
+	})
+}
+
+func FuzzAppendedDescWithBuf(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedDescWithBuf(t, initial, tailLenght)
+	})
+}
+
+func testSortDescendingRun(t *testing.T, initial []byte) {
+	s := make([]int, len(initial))
+	for idx, v := range initial {
+		s[idx] = int(v)
+	}
+	expected := make([]int, len(s))
+	copy(expected, s)
+	stdsort.Ints(expected)
+	stdslices.Reverse(expected)
+	t.Run(fmt.Sprintf("%v", initial), func(t *testing.T) {
+		SortDescendingRun(intSlice(s))
+		if !intsEqual(expected, s) {
+			t.Fatalf("%v != %v", expected, s)
+		}
+	})
+}
+
+func TestSortDescendingRun(t *testing.T) {
+	testSortDescendingRun(t, []byte{})
+	testSortDescendingRun(t, []byte{1})
+	testSortDescendingRun(t, []byte{3, 1, 4, 1, 5, 9, 2, 6})
+	testSortDescendingRun(t, []byte{9, 6, 5, 4, 3, 2, 1, 1})
+	testSortDescendingRun(t, []byte{1, 1, 2, 3, 4, 5, 6, 9})
+}
+
+func FuzzSortDescendingRun(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		testSortDescendingRun(t, initial)
+	})
+}
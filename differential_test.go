@@ -0,0 +1,48 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/go-ng/xsort"
+	"github.com/go-ng/xsort/xsorttest"
+)
+
+type byteKeySeq struct {
+	Key byte
+	Seq int
+}
+
+type byteKeySeqs []byteKeySeq
+
+func (s byteKeySeqs) Less(i, j int) bool {
+	return s[i].Key < s[j].Key
+}
+
+func FuzzDifferential(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLength := uint(rand.Intn(len(initial) + 1))
+		s := make(byteKeySeqs, len(initial))
+		for idx, v := range initial {
+			s[idx] = byteKeySeq{Key: v, Seq: idx}
+		}
+		prefix := s[:len(s)-int(tailLength)]
+		sort.Slice(prefix, func(i, j int) bool {
+			return prefix[i].Key < prefix[j].Key
+		})
+		xsorttest.AssertSameResult(t, s, tailLength)
+	})
+}
+
+func TestAppendedRandom(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		s, tailLength := xsorttest.RandomAppendedSlice(1000, 10*int(seed), seed)
+		xsort.Appended(sort.IntSlice(s), tailLength)
+		xsorttest.CheckSorted(t, s)
+	}
+}
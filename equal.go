@@ -0,0 +1,42 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+// Equal returns true if the slices have the same length and the same
+// elements in the same order. It is useful to compare a sorted slice
+// with the expected result in tests.
+//
+// T: O(n)
+//
+// S: O(1)
+func Equal[E comparable](a, b []E) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for idx := range a {
+		if a[idx] != b[idx] {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualFunc is the same as Equal, but the elements are compared using
+// the provided function.
+//
+// T: O(n)
+//
+// S: O(1)
+func EqualFunc[E any](a, b []E, eq func(a, b E) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for idx := range a {
+		if !eq(a[idx], b[idx]) {
+			return false
+		}
+	}
+	return true
+}
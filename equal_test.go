@@ -0,0 +1,56 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	for _, testCase := range []struct {
+		a, b     []int
+		expected bool
+	}{
+		{nil, nil, true},
+		{nil, []int{}, true},
+		{[]int{1, 2, 3}, []int{1, 2, 3}, true},
+		{[]int{1, 2, 3}, []int{1, 2}, false},
+		{[]int{1, 2}, []int{1, 2, 3}, false},
+		{[]int{1, 2, 3}, []int{1, 2, 4}, false},
+		{[]int{0, 2, 3}, []int{1, 2, 3}, false},
+	} {
+		t.Run(fmt.Sprintf("%v_%v", testCase.a, testCase.b), func(t *testing.T) {
+			if result := Equal(testCase.a, testCase.b); result != testCase.expected {
+				t.Fatalf("Equal: %v != %v", result, testCase.expected)
+			}
+			eq := func(a, b int) bool { return a == b }
+			if result := EqualFunc(testCase.a, testCase.b, eq); result != testCase.expected {
+				t.Fatalf("EqualFunc: %v != %v", result, testCase.expected)
+			}
+		})
+	}
+
+	t.Run("EqualFunc_NaN", func(t *testing.T) {
+		a := []float64{1, math.NaN()}
+		b := []float64{1, math.NaN()}
+		if Equal(a, b) {
+			t.Fatal("NaN is not expected to be equal to NaN with Equal")
+		}
+		if !EqualFunc(a, b, func(a, b float64) bool {
+			return a == b || (math.IsNaN(a) && math.IsNaN(b))
+		}) {
+			t.Fatal("expected to be equal with EqualFunc")
+		}
+	})
+
+	t.Run("allocs", func(t *testing.T) {
+		a, b := []int{1, 2, 3}, []int{1, 2, 3}
+		if allocs := testing.AllocsPerRun(10, func() { Equal(a, b) }); allocs != 0 {
+			t.Fatalf("unexpected allocations: %v", allocs)
+		}
+	})
+}
@@ -0,0 +1,51 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+)
+
+// EstimateTailLength estimates the length of the unsorted tail of the
+// slice (to be passed to Appended) by scanning at most maxScan last
+// elements: the tail starts at the leftmost unsorted pair of neighbours
+// among the scanned elements.
+//
+// confident is false if the disorder may extend beyond the scanned
+// elements (then it is better to sort the whole slice). The elements
+// before the scanned ones are not checked at all, see
+// IsAppendedSortable to verify the estimation.
+//
+// T: O(m), where `m` is maxScan
+//
+// S: O(1)
+func EstimateTailLength[E any, S Interface[E]](s S, maxScan int) (tailLength uint, confident bool) {
+	if maxScan < 0 {
+		panic(fmt.Sprintf("maxScan (%d) cannot be negative", maxScan))
+	}
+	length := len(s)
+	if length < 2 {
+		return 0, true
+	}
+
+	// the scanned elements are s[scanStart-1:]
+	scanStart := length - maxScan + 1
+	if scanStart < 1 {
+		scanStart = 1
+	}
+	if scanStart > length {
+		scanStart = length
+	}
+	splitIdx := length
+	for idx := length - 1; idx >= scanStart; idx-- {
+		if s.Less(idx, idx-1) {
+			splitIdx = idx
+		}
+	}
+	if splitIdx == scanStart && scanStart > 1 {
+		return uint(length - splitIdx), false
+	}
+	return uint(length - splitIdx), true
+}
@@ -0,0 +1,153 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestEstimateTailLength(t *testing.T) {
+	for _, testCase := range []struct {
+		s                  intSlice
+		maxScan            int
+		expectedTailLength uint
+		expectedConfident  bool
+	}{
+		{nil, 10, 0, true},
+		{intSlice{1}, 0, 0, true},
+		{intSlice{1, 2, 3, 4, 5}, 10, 0, true},
+		{intSlice{1, 2, 3, 4, 5}, 2, 0, true},
+		{intSlice{1, 2, 3, 4, 5}, 0, 0, false},
+		{intSlice{1, 3, 5, 7, 9, 2, 4}, 1, 0, false},
+		{intSlice{1, 3, 5, 7, 9, 2, 4}, 3, 2, false},
+		{intSlice{1, 3, 5, 7, 9, 2, 4}, 4, 2, true},
+		{intSlice{1, 3, 5, 7, 9, 2, 4}, 10, 2, true},
+		{intSlice{1, 3, 5, 7, 9, 2, 10, 4}, 5, 3, true},
+		// the disorder is beyond maxScan
+		{intSlice{1, 3, 5, 7, 9, 2, 10, 4}, 2, 1, false},
+		// the disorder is beyond maxScan, but it cannot be detected,
+		// because the first scanned pair is sorted
+		{intSlice{1, 3, 5, 7, 9, 2, 10, 4}, 3, 1, true},
+		{intSlice{5, 4, 3, 2, 1}, 4, 3, false},
+		{intSlice{5, 4, 3, 2, 1}, 5, 4, true},
+		{intSlice{5, 4, 3, 2, 1}, 10, 4, true},
+	} {
+		t.Run(fmt.Sprintf("%v/maxScan-%d", testCase.s, testCase.maxScan), func(t *testing.T) {
+			tailLength, confident := EstimateTailLength(testCase.s, testCase.maxScan)
+			if tailLength != testCase.expectedTailLength || confident != testCase.expectedConfident {
+				t.Fatalf("(%d, %t) != (%d, %t)", tailLength, confident, testCase.expectedTailLength, testCase.expectedConfident)
+			}
+		})
+	}
+
+	t.Run("negative_maxScan", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		EstimateTailLength(intSlice{1, 2}, -1)
+	})
+
+	t.Run("read_elements", func(t *testing.T) {
+		for length := 0; length <= 10; length++ {
+			for maxScan := 0; maxScan <= 12; maxScan++ {
+				s := make(readInts, length)
+				read := make([]bool, length)
+				for idx := range s {
+					s[idx] = readInt{v: length - idx, read: &read[idx]}
+				}
+				EstimateTailLength(s, maxScan)
+
+				expectedReads := min(maxScan, length)
+				if expectedReads < 2 {
+					// there is no pair to compare
+					expectedReads = 0
+				}
+				var reads int
+				for idx, isRead := range read {
+					if !isRead {
+						continue
+					}
+					reads++
+					if idx < length-expectedReads {
+						t.Fatalf("length %d, maxScan %d: element %d was read", length, maxScan, idx)
+					}
+				}
+				if reads != expectedReads {
+					t.Fatalf("length %d, maxScan %d: %d elements were read instead of %d", length, maxScan, reads, expectedReads)
+				}
+			}
+		}
+	})
+}
+
+// readInt is an int, which marks itself as read when compared.
+type readInt struct {
+	v    int
+	read *bool
+}
+
+type readInts []readInt
+
+func (s readInts) Len() int {
+	return len(s)
+}
+
+func (s readInts) Less(i, j int) bool {
+	*s[i].read, *s[j].read = true, true
+	return s[i].v < s[j].v
+}
+
+func (s readInts) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+func FuzzEstimateTailLength(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		s, _, _, _ := prepareTestCase(initial, tailLenght)
+		maxScan := rand.Intn(len(s) + 2)
+
+		tailLength, confident := EstimateTailLength(intSlice(s), maxScan)
+		if tailLength > tailLenght {
+			t.Fatalf("the estimated tail is longer than the real one: %d > %d (%v, maxScan: %d)", tailLength, tailLenght, s, maxScan)
+		}
+		if maxScan >= len(s) {
+			// the whole slice is scanned
+			if !confident {
+				t.Fatalf("expected to be confident (%v, maxScan: %d)", s, maxScan)
+			}
+			if !IsAppendedSortable(intSlice(s), tailLength) {
+				t.Fatalf("the prefix is not sorted for the estimated tail %d (%v)", tailLength, s)
+			}
+			return
+		}
+		scanned := s[len(s)-maxScan : len(s)-int(tailLength)]
+		if !confident && len(scanned) > 1 {
+			scanned = scanned[1:]
+		}
+		if !IsAppendedSortable(intSlice(scanned), 0) {
+			t.Fatalf("the scanned part of the prefix is not sorted for the estimated tail %d (%v, maxScan: %d)", tailLength, s, maxScan)
+		}
+	})
+}
+
+func BenchmarkEstimateTailLength(b *testing.B) {
+	s := make(intSlice, 65536)
+	for idx := range s {
+		s[idx] = idx
+	}
+	for _, maxScan := range []int{16, 256} {
+		b.Run(fmt.Sprintf("total-%d/maxScan-%d", len(s), maxScan), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				EstimateTailLength(s, maxScan)
+			}
+		})
+	}
+}
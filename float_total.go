@@ -0,0 +1,43 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"math"
+)
+
+// AppendedFloat64Total is the same as AppendedFloat64, but the elements
+// are ordered by the IEEE 754 total order (`totalOrder`): negative NaNs
+// go first, then -Inf, the negative numbers, -0.0, +0.0, the positive
+// numbers, +Inf and the positive NaNs. NaNs with different bit patterns
+// are ordered by their payloads, thus the result is deterministic
+// (up to the bit pattern of each element).
+//
+// It is useful for a reproducible serialization.
+func AppendedFloat64Total(s []float64, tailLength uint) {
+	Appended(float64Total(s), tailLength)
+}
+
+// float64Total implements Interface for a slice of float64 in
+// the IEEE 754 total order.
+type float64Total []float64
+
+// Less implements Interface.
+func (s float64Total) Less(i, j int) bool {
+	return float64TotalKey(s[i]) < float64TotalKey(s[j])
+}
+
+// float64TotalKey maps float64 to uint64 such that the order of the keys
+// is the IEEE 754 total order of the values: the bits of the negative
+// values are inverted (so that a larger magnitude gives a lower key),
+// while the positive values get the sign bit set (so that they go after
+// the negative ones).
+func float64TotalKey(v float64) uint64 {
+	bits := math.Float64bits(v)
+	if bits&(1<<63) != 0 {
+		return ^bits
+	}
+	return bits | 1<<63
+}
@@ -0,0 +1,120 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/rand"
+	stdsort "sort"
+	"testing"
+)
+
+func testAppendedFloat64Total(t *testing.T, initial []float64, tailLenght uint) {
+	initial = append([]float64{}, initial...)
+	splitIdx := len(initial) - int(tailLenght)
+	stdsort.Slice(initial[:splitIdx], func(i, j int) bool {
+		return float64TotalKey(initial[i]) < float64TotalKey(initial[j])
+	})
+	t.Run(fmt.Sprintf("%v (tailLength: %d)", initial, tailLenght), func(t *testing.T) {
+		s := append([]float64{}, initial...)
+		AppendedFloat64Total(s, tailLenght)
+
+		expected := make([]uint64, len(initial))
+		for idx, v := range initial {
+			expected[idx] = math.Float64bits(v)
+		}
+		stdsort.Slice(expected, func(i, j int) bool {
+			return float64TotalKey(math.Float64frombits(expected[i])) < float64TotalKey(math.Float64frombits(expected[j]))
+		})
+		for idx, v := range s {
+			if math.Float64bits(v) != expected[idx] {
+				t.Fatalf("unexpected bits at %d: %x != %x", idx, math.Float64bits(v), expected[idx])
+			}
+		}
+	})
+}
+
+func TestAppendedFloat64Total(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	nan1 := math.Float64frombits(0x7ff8000000000001)
+	nan2 := math.Float64frombits(0x7ff8000000000002)
+	negNaN := math.Float64frombits(0xfff8000000000000)
+
+	prefix := []float64{-3, -1, 0.5, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17}
+	s := append(append([]float64{}, prefix...), nan2, 0, math.Inf(1), negNaN, nan1, negZero, math.Inf(-1))
+	AppendedFloat64Total(s, 7)
+
+	expected := append(append(append(
+		[]float64{negNaN, math.Inf(-1)}, prefix[:2]...),
+		negZero, 0),
+		append(prefix[2:], math.Inf(1), nan1, nan2)...)
+	for idx := range expected {
+		if math.Float64bits(s[idx]) != math.Float64bits(expected[idx]) {
+			t.Fatalf("%v != %v", s, expected)
+		}
+	}
+
+	testAppendedFloat64Total(t, s, 0)
+	testAppendedFloat64Total(t, []float64{0, negZero, nan1, nan2, negNaN, nan2, 0}, 4)
+}
+
+func FuzzAppendedFloat64Total(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		// each 8 bytes are a bit pattern, so any NaN payloads are covered
+		s := make([]float64, len(initial)/8)
+		for idx := range s {
+			s[idx] = math.Float64frombits(binary.LittleEndian.Uint64(initial[idx*8:]))
+		}
+		tailLenght := uint(rand.Intn(len(s) + 1))
+		testAppendedFloat64Total(t, s, tailLenght)
+	})
+}
+
+func BenchmarkAppendedFloat64Total(b *testing.B) {
+	const (
+		totalSize = 65536
+		csCount   = 20
+	)
+	for _, tailSize := range []int{16, 1024} {
+		rng := rand.New(rand.NewSource(0))
+		in := make([][]float64, csCount)
+		for idx := range in {
+			in[idx] = make([]float64, totalSize)
+			s := in[idx]
+			for idx := range s {
+				s[idx] = rng.NormFloat64()
+			}
+			stdsort.Float64s(s[:totalSize-tailSize])
+		}
+		cs := make([][]float64, csCount)
+		for idx := range cs {
+			cs[idx] = make([]float64, totalSize)
+		}
+
+		for _, f := range []struct {
+			name string
+			fn   func([]float64, uint)
+		}{
+			{name: "AppendedFloat64", fn: AppendedFloat64},
+			{name: "AppendedFloat64Total", fn: AppendedFloat64Total},
+		} {
+			b.Run(fmt.Sprintf("total-%d/tail-%d/%s", totalSize, tailSize, f.name), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range cs {
+							copy(cs[idx], in[idx])
+						}
+						b.StartTimer()
+					}
+					f.fn(cs[idx], uint(tailSize))
+				}
+			})
+		}
+	}
+}
@@ -1,6 +1,6 @@
 module github.com/go-ng/xsort
 
-go 1.18
+go 1.21
 
 require (
 	github.com/go-ng/container v0.0.0-20220615121757-4740bf4bbc52
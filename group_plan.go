@@ -0,0 +1,115 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	stdsort "sort"
+
+	"github.com/go-ng/sort"
+)
+
+// appendedGroupPlan returns the sizes of the groups Appended moves on each
+// step of the group insertion for a slice of totalSize elements with
+// an unsorted tail of tailLength elements: on each step the greatest
+// element of the group is inserted and the rest of the group (one element
+// less) is moved further to the left, so the sizes are tailLength,
+// tailLength-1, ..., 1 and their sum is the "k^2" term of the time
+// complexity of Appended.
+//
+// It depends only on the sizes, so it describes the worst case: with
+// the actual data the insertion may end earlier (see appendedGroupSteps)
+// or the tail may be just sorted (StrategySortTail).
+//
+// It returns nil if Appended does not use the group insertion for
+// the given sizes (see chooseAppendedStrategySeq).
+//
+// T: O(k)
+//
+// S: O(k)
+func appendedGroupPlan(totalSize, tailLength uint) []int {
+	if !(appendedPolicy{}).shouldGroupInsert(totalSize, tailLength) {
+		return nil
+	}
+	plan := make([]int, tailLength)
+	for idx := range plan {
+		plan[idx] = int(tailLength) - idx
+	}
+	return plan
+}
+
+// groupStep is a step of the group insertion (see appendedGroupSteps).
+type groupStep struct {
+	// size is the size of the unsorted group on the step.
+	size int
+
+	// insertIdx is the final index of the inserted element (the greatest
+	// one of the group).
+	insertIdx int
+
+	// moved is the amount of the prefix elements moved over the group
+	// (to the right).
+	moved int
+}
+
+// appendedGroupSteps returns the steps Appended (in groupInsertDescendingTail)
+// would perform for the given slice, without touching any data: on each
+// step the greatest element of the unsorted group gets its final position,
+// found by the same binary search as the one of the merge, while the rest
+// of the group is moved to the left (over the greater elements of
+// the prefix) as a whole. Thus the sum of the sizes is the "k^2" term of
+// the time complexity of Appended, and the sum of the moved elements is
+// the "n" term.
+//
+// If a group reaches the beginning of the slice, the rest of the group
+// is just reversed in place, so the plan ends there (it may be shorter
+// than the tail).
+//
+// It returns nil if Appended does not use the group insertion for
+// the given slice (see chooseAppendedStrategy).
+//
+// T: O(k*ln(n))
+//
+// S: O(k)
+func appendedGroupSteps[E any, S Interface[E]](s S, tailLength uint) []groupStep {
+	if chooseAppendedStrategy(s, tailLength) != StrategyGroupInsert {
+		return nil
+	}
+	splitIdx := len(s) - int(tailLength)
+	if splitIdx == 0 {
+		// the whole slice is sorted instead
+		return nil
+	}
+
+	// tail is the indexes of the tail elements in descending order of
+	// the elements (the order Appended inserts them in).
+	tail := make([]int, tailLength)
+	for idx := range tail {
+		tail[idx] = splitIdx + idx
+	}
+	stdsort.SliceStable(tail, func(i, j int) bool {
+		return s.Less(tail[j], tail[i])
+	})
+
+	// The elements left of the group are always the untouched elements
+	// of the prefix, so the searches are done over the original prefix.
+	plan := make([]groupStep, 0, tailLength)
+	unsortedStartIdx := splitIdx
+	for idx, tailIdx := range tail {
+		if unsortedStartIdx == 0 {
+			break
+		}
+		unsortedCount := int(tailLength) - idx
+		leftIdx := sort.Search(unsortedStartIdx, func(i int) bool {
+			return s.Less(tailIdx, i)
+		})
+		plan = append(plan, groupStep{
+			size:      unsortedCount,
+			insertIdx: leftIdx + unsortedCount - 1,
+			moved:     unsortedStartIdx - leftIdx,
+		})
+		unsortedStartIdx = leftIdx
+	}
+	return plan
+}
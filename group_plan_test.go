@@ -0,0 +1,170 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	stdsort "sort"
+	"testing"
+)
+
+func TestAppendedGroupPlan(t *testing.T) {
+	for _, testCase := range []struct {
+		totalSize  uint
+		tailLength uint
+		expected   []int
+	}{
+		{totalSize: 16, tailLength: 1, expected: []int{1}},
+		{totalSize: 18, tailLength: 2, expected: []int{2, 1}},
+		{totalSize: 100, tailLength: 5, expected: []int{5, 4, 3, 2, 1}},
+		{totalSize: 18, tailLength: 0, expected: nil},
+		// below MinAppendedSize
+		{totalSize: 5, tailLength: 1, expected: nil},
+		// the whole slice is the tail
+		{totalSize: 16, tailLength: 16, expected: nil},
+		// the tail is too long, fallback to a full sort
+		{totalSize: 16, tailLength: 4, expected: nil},
+	} {
+		t.Run(fmt.Sprintf("%d/tail-%d", testCase.totalSize, testCase.tailLength), func(t *testing.T) {
+			plan := appendedGroupPlan(testCase.totalSize, testCase.tailLength)
+			if fmt.Sprint(plan) != fmt.Sprint(testCase.expected) || (plan == nil) != (testCase.expected == nil) {
+				t.Fatalf("%v != %v", plan, testCase.expected)
+			}
+
+			// the sizes must be consistent with the steps of
+			// the actual insertion in the worst case (the whole tail
+			// is less than the prefix, but not less than its first element)
+			s := make(intSlice, testCase.totalSize)
+			splitIdx := testCase.totalSize - testCase.tailLength
+			for idx := range s {
+				s[idx] = 2 * idx
+			}
+			for idx := splitIdx; idx < testCase.totalSize; idx++ {
+				s[idx] = 1
+			}
+			steps := appendedGroupSteps(s, testCase.tailLength)
+			if len(steps) != len(plan) {
+				t.Fatalf("plan %v does not match the steps %v", plan, steps)
+			}
+			for idx, step := range steps {
+				if step.size != plan[idx] {
+					t.Fatalf("plan %v does not match the steps %v", plan, steps)
+				}
+			}
+		})
+	}
+}
+
+func TestAppendedGroupSteps(t *testing.T) {
+	for _, testCase := range []struct {
+		s          intSlice
+		tailLength uint
+		expected   []groupStep
+	}{
+		{
+			s:          intSlice{1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 21, 23, 25, 27, 29, 31, 10, 2},
+			tailLength: 2,
+			expected:   []groupStep{{size: 2, insertIdx: 6, moved: 11}, {size: 1, insertIdx: 1, moved: 4}},
+		},
+		{
+			s:          intSlice{1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 21, 23, 25, 27, 29, 31, 32, 2},
+			tailLength: 2,
+			expected:   []groupStep{{size: 2, insertIdx: 17, moved: 0}, {size: 1, insertIdx: 1, moved: 15}},
+		},
+		// the group reaches the beginning of the slice, the rest is reversed
+		{
+			s:          intSlice{1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 21, 23, 25, 27, 29, 31, 0, -1},
+			tailLength: 2,
+			expected:   []groupStep{{size: 2, insertIdx: 1, moved: 16}},
+		},
+		// the tail is after the prefix
+		{
+			s:          intSlice{1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 21, 23, 25, 27, 29, 31, 33, 32},
+			tailLength: 2,
+			expected:   nil,
+		},
+		{
+			s:          intSlice{1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 21, 23, 25, 27, 29, 31, 33, 32},
+			tailLength: 0,
+			expected:   nil,
+		},
+		// below MinAppendedSize
+		{
+			s:          intSlice{1, 3, 5, 7, 0},
+			tailLength: 1,
+			expected:   nil,
+		},
+		// the tail is too long, fallback to a full sort
+		{
+			s:          intSlice{1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 21, 23, 14, 12, 10, 8},
+			tailLength: 4,
+			expected:   nil,
+		},
+	} {
+		t.Run(fmt.Sprintf("%v/tail-%d", testCase.s, testCase.tailLength), func(t *testing.T) {
+			plan := appendedGroupSteps(testCase.s, testCase.tailLength)
+			if len(plan) != len(testCase.expected) {
+				t.Fatalf("%v != %v", plan, testCase.expected)
+			}
+			for idx := range plan {
+				if plan[idx] != testCase.expected[idx] {
+					t.Fatalf("%v != %v", plan, testCase.expected)
+				}
+			}
+		})
+	}
+
+	t.Run("matches_the_algorithm", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(0))
+		const totalSize, tailLength = 1000, 20
+		for run := 0; run < 100; run++ {
+			s := make(intSlice, totalSize)
+			for idx := range s {
+				s[idx] = rng.Intn(totalSize)
+			}
+			stdsort.Ints(s[:totalSize-tailLength])
+			tail := make([]int, tailLength)
+			copy(tail, s[totalSize-tailLength:])
+			stdsort.Sort(stdsort.Reverse(stdsort.IntSlice(tail)))
+
+			plan := appendedGroupSteps(s, tailLength)
+			if len(plan) == 0 {
+				t.Fatalf("no plan for %v", s)
+			}
+
+			sortTailDescending(s[totalSize-tailLength:])
+			var branches []string
+			groupInsertDescendingTailLimited(s, totalSize-tailLength, math.MaxInt, false, func(branch string, _ uint) {
+				branches = append(branches, branch)
+			})
+			if !stdsort.IntsAreSorted(s) {
+				t.Fatalf("not sorted")
+			}
+
+			if len(plan) < tailLength {
+				// the rest of the group was reversed
+				if len(branches) != len(plan)+1 || branches[len(plan)] != BranchReverse {
+					t.Fatalf("plan %v does not match the branches %v", plan, branches)
+				}
+			} else if len(branches) != len(plan) {
+				t.Fatalf("plan %v does not match the branches %v", plan, branches)
+			}
+			for idx, step := range plan {
+				expectedBranch := BranchBigRotate
+				if step.moved == 0 {
+					expectedBranch = BranchRotate1
+				}
+				if step.size != tailLength-idx || branches[idx] != expectedBranch {
+					t.Fatalf("plan %v does not match the branches %v", plan, branches)
+				}
+				if s[step.insertIdx] != tail[idx] {
+					t.Fatalf("step %d of plan %v: %d is not at %d in %v", idx, plan, tail[idx], step.insertIdx, s)
+				}
+			}
+		}
+	})
+}
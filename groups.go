@@ -0,0 +1,32 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+// SortedGroups splits the slice, which is already sorted by key (for
+// example with AppendedBy2), into runs of elements with equal keys.
+//
+// The returned groups are sub-slices of s (they alias the input, not
+// copies). The capacity of each group is limited by its length, so
+// appending to a group does not overwrite the next group.
+//
+// T: O(n)
+func SortedGroups[E any, K comparable](s []E, key func(E) K) [][]E {
+	if len(s) == 0 {
+		return nil
+	}
+
+	var groups [][]E
+	startIdx := 0
+	startKey := key(s[0])
+	for idx := 1; idx < len(s); idx++ {
+		k := key(s[idx])
+		if k == startKey {
+			continue
+		}
+		groups = append(groups, s[startIdx:idx:idx])
+		startIdx, startKey = idx, k
+	}
+	return append(groups, s[startIdx:len(s):len(s)])
+}
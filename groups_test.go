@@ -0,0 +1,49 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSortedGroups(t *testing.T) {
+	identity := func(v int) int { return v }
+	for _, testCase := range []struct {
+		in       []int
+		expected [][]int
+	}{
+		{in: nil, expected: nil},
+		{in: []int{1}, expected: [][]int{{1}}},
+		{in: []int{1, 2, 3}, expected: [][]int{{1}, {2}, {3}}},
+		{in: []int{1, 1, 2, 3, 3, 3}, expected: [][]int{{1, 1}, {2}, {3, 3, 3}}},
+		{in: []int{5, 5, 5}, expected: [][]int{{5, 5, 5}}},
+	} {
+		t.Run(fmt.Sprint(testCase.in), func(t *testing.T) {
+			groups := SortedGroups(testCase.in, identity)
+			if len(groups) != len(testCase.expected) {
+				t.Fatalf("%v != %v", groups, testCase.expected)
+			}
+			for idx := range groups {
+				if !intsEqual(groups[idx], testCase.expected[idx]) {
+					t.Fatalf("%v != %v", groups, testCase.expected)
+				}
+			}
+		})
+	}
+
+	t.Run("aliasing", func(t *testing.T) {
+		s := []int{1, 1, 2}
+		groups := SortedGroups(s, identity)
+		groups[0][1] = 7
+		if s[1] != 7 {
+			t.Fatalf("the groups are expected to alias the input")
+		}
+		_ = append(groups[0], 9)
+		if s[2] != 2 {
+			t.Fatalf("appending to a group should not overwrite the next group")
+		}
+	})
+}
@@ -0,0 +1,51 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+
+	"github.com/go-ng/slices"
+	"github.com/go-ng/sort"
+)
+
+// AppendedHeadTail is the same as Appended, but the unsorted elements are
+// both at the beginning and at the end of the slice: it assumes
+// `s[headLength:len(s)-tailLength]` is already sorted.
+//
+// The head is merged into the sorted middle the same way as a tail
+// (using a reversed view of the slice), and then the tail is merged.
+// If the unsorted parts are too long (or the slice is shorter than
+// MinAppendedSize), then the whole slice is just sorted.
+//
+// T: O((h+k)*ln(n) + n + h^2 + k^2)
+//
+// S: O(1) [if without `s`]
+func AppendedHeadTail[E any, S Interface[E]](s S, headLength, tailLength uint) {
+	if headLength > uint(len(s)) || tailLength > uint(len(s))-headLength {
+		panic(fmt.Sprintf("headLength (%d) + tailLength (%d) cannot be greater than the length of the provided slice (%d)", headLength, tailLength, len(s)))
+	}
+	if headLength == 0 {
+		Appended(s, tailLength)
+		return
+	}
+	// the head and the tail are reported to OnStrategy as a single tail
+	if startAppended(s, headLength+tailLength, appendedPolicy{noSortTail: true}) != StrategyGroupInsert {
+		sort.Sort(s)
+		return
+	}
+
+	// Reversing `head middle` gives `reversed(middle) reversed(head)`,
+	// where reversed(middle) is sorted in descending order, so the head
+	// becomes a tail of a slice sorted in descending order.
+	headAndMiddle := s[:uint(len(s))-tailLength]
+	slices.Reverse(headAndMiddle)
+	groupInsertAppendSort(descending[E, S](headAndMiddle), headLength)
+	slices.Reverse(headAndMiddle)
+
+	if tailLength > 0 {
+		groupInsertAppendSort(s, tailLength)
+	}
+}
@@ -0,0 +1,112 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"testing"
+)
+
+func testAppendedHeadTail(t *testing.T, initial []byte, headLength, tailLength uint) {
+	s := make([]int, len(initial))
+	for idx, v := range initial {
+		s[idx] = int(v)
+	}
+	stdsort.Ints(s[headLength : uint(len(s))-tailLength])
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(fmt.Sprintf("%v/head-%d/tail-%d", s, headLength, tailLength), func(t *testing.T) {
+		AppendedHeadTail(intSlice(s), headLength, tailLength)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v", c, s)
+		}
+	})
+}
+
+func TestAppendedHeadTail(t *testing.T) {
+	testAppendedHeadTail(t, []byte{}, 0, 0)
+	testAppendedHeadTail(t, []byte{3, 2, 1}, 3, 0)
+	testAppendedHeadTail(t, []byte{3, 2, 1}, 1, 2)
+	testAppendedHeadTail(t, []byte{12, 6, 4, 1, 3, 5, 7, 11, 13, 8}, 3, 1)
+	testAppendedHeadTail(t, []byte{15, 0, 0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14, 0}, 1, 5)
+	testAppendedHeadTail(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 0, 4)
+
+	rng := rand.New(rand.NewSource(0))
+	initial := make([]byte, 1000)
+	rng.Read(initial)
+	for _, lengths := range [][2]uint{{1, 1}, {10, 0}, {10, 10}, {20, 3}, {500, 500}} {
+		testAppendedHeadTail(t, initial, lengths[0], lengths[1])
+	}
+
+	t.Run("too_long", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		AppendedHeadTail(make(intSlice, 10), 6, 5)
+	})
+}
+
+func FuzzAppendedHeadTail(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		headLength := uint(rand.Intn(len(initial) + 1))
+		tailLength := uint(rand.Intn(len(initial) - int(headLength) + 1))
+		testAppendedHeadTail(t, initial, headLength, tailLength)
+	})
+}
+
+func BenchmarkAppendedHeadTail(b *testing.B) {
+	const (
+		totalSize = 65536
+		csCount   = 20
+	)
+	for _, unsortedSize := range []int{16, 512} {
+		rng := rand.New(rand.NewSource(0))
+		in := make([][]int, csCount)
+		for idx := range in {
+			in[idx] = make([]int, totalSize)
+			s := in[idx]
+			for idx := range s {
+				s[idx] = rng.Intn(totalSize)
+			}
+			stdsort.Ints(s[unsortedSize : totalSize-unsortedSize])
+		}
+
+		cs := make([]intSlice, csCount)
+		for idx := range cs {
+			cs[idx] = make([]int, totalSize)
+		}
+
+		for _, f := range []struct {
+			name string
+			fn   func(intSlice)
+		}{
+			{name: "Sort", fn: Sort[int, intSlice]},
+			{name: "AppendedHeadTail", fn: func(s intSlice) {
+				AppendedHeadTail(s, uint(unsortedSize), uint(unsortedSize))
+			}},
+		} {
+			b.Run(fmt.Sprintf("total-%d/head-%d/tail-%d/%s", totalSize, unsortedSize, unsortedSize, f.name), func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range cs {
+							copy(cs[idx], in[idx])
+						}
+						b.StartTimer()
+					}
+					f.fn(cs[idx])
+				}
+			})
+		}
+	}
+}
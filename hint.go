@@ -0,0 +1,111 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+
+	"github.com/go-ng/slices"
+	"github.com/go-ng/sort"
+)
+
+// AppendedHint describes the expected distribution of the values in
+// the unsorted tail. See AppendedWithHint.
+type AppendedHint int
+
+const (
+	// HintUnknown means nothing is known about the tail. It is handled
+	// the same way as in Appended: the tail is checked in O(k) if it is
+	// already sorted in descending order, and sorted otherwise.
+	HintUnknown AppendedHint = iota
+
+	// HintNearlySorted means the tail is almost sorted in ascending order
+	// (only few elements are slightly displaced). The tail is sorted using
+	// NearlySorted, which is O(k) on an already sorted tail, and thus
+	// the optimization is used for longer tails than in Appended.
+	HintNearlySorted
+
+	// HintReverseSorted means the tail is (probably) sorted in descending
+	// order. The tail is checked in O(k) and if it is indeed sorted
+	// in descending order, then it is not sorted at all (this is exactly
+	// the order the algorithm needs), and thus the optimization is used
+	// for longer tails than in Appended.
+	HintReverseSorted
+
+	// HintUniform means the tail consists of randomly ordered values.
+	// The tail is sorted without checking if it is already sorted
+	// in descending order.
+	HintUniform
+)
+
+// String implements fmt.Stringer.
+func (hint AppendedHint) String() string {
+	switch hint {
+	case HintUnknown:
+		return "Unknown"
+	case HintNearlySorted:
+		return "NearlySorted"
+	case HintReverseSorted:
+		return "ReverseSorted"
+	case HintUniform:
+		return "Uniform"
+	default:
+		return fmt.Sprintf("AppendedHint(%d)", int(hint))
+	}
+}
+
+// AppendedWithHint is the same as Appended, but it accepts a hint about
+// the distribution of values in the unsorted tail, which is used to choose
+// how to sort the tail before merging it into the sorted prefix.
+//
+// The merge itself (and its "k^2" term, which defines when to fallback
+// to a full sort) does not depend on the order of the tail, but a tail
+// which is already (almost) sorted is sorted in O(k) instead of
+// O(k*ln(k)). Thus with HintNearlySorted and HintReverseSorted
+// the fallback threshold is ~10% higher (see sortedTailDiscountDivisor),
+// while with HintUniform and HintUnknown it is the same as in Appended.
+// So the hints are useful mostly for big tails or expensive comparisons.
+//
+// An incorrect hint does not break the result, it only makes it slower.
+func AppendedWithHint[E any, S Interface[E]](s S, tailLength uint, hint AppendedHint) {
+	strategy := startAppended(s, tailLength, appendedHintPolicy(hint))
+	tail := s[uint(len(s))-tailLength:]
+	switch {
+	case strategy == StrategySortTail && hint == HintNearlySorted:
+		NearlySorted(tail)
+	case strategy == StrategySortTail && hint == HintReverseSorted:
+		sortTailDescending(tail)
+		slices.Reverse(tail)
+	case strategy == StrategyGroupInsert:
+		sortTailDescendingWithHint(tail, hint)
+		groupInsertDescendingTail(s, uint(len(s))-tailLength)
+	default:
+		finishAppended(s, tailLength, strategy)
+	}
+}
+
+// appendedHintPolicy returns the policy of AppendedWithHint for the hint.
+func appendedHintPolicy(hint AppendedHint) appendedPolicy {
+	return appendedPolicy{
+		sortedTail: hint == HintNearlySorted || hint == HintReverseSorted,
+	}
+}
+
+// sortTailDescendingWithHint sorts the tail in descending order, choosing
+// the method according to the hint.
+func sortTailDescendingWithHint[E any, S Interface[E]](tail S, hint AppendedHint) {
+	switch hint {
+	case HintNearlySorted:
+		NearlySorted(tail)
+		slices.Reverse(tail)
+		return
+	case HintUniform:
+		sort.Slice(tail, func(i, j int) bool {
+			return tail.Less(j, i)
+		})
+		return
+	}
+	sortTailDescending(tail)
+}
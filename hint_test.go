@@ -0,0 +1,156 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+// arrangeTail rearranges the tail according to the hint (to simulate
+// the distribution the hint describes).
+func arrangeTail(rng *rand.Rand, tail []int, hint AppendedHint) {
+	switch hint {
+	case HintNearlySorted:
+		stdsort.Ints(tail)
+		for i := 0; i < len(tail)/20; i++ {
+			a, b := rng.Intn(len(tail)), rng.Intn(len(tail))
+			if b-a > 8 || a-b > 8 {
+				continue
+			}
+			tail[a], tail[b] = tail[b], tail[a]
+		}
+	case HintReverseSorted:
+		stdsort.Sort(stdsort.Reverse(stdsort.IntSlice(tail)))
+	}
+}
+
+func testAppendedWithHint(t *testing.T, initial []byte, tailLenght uint, hint AppendedHint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(fmt.Sprintf("%s (hint: %s)", testName, hint), func(t *testing.T) {
+		AppendedWithHint(intSlice(s), tailLenght, hint)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedWithHint(t *testing.T) {
+	for hint := HintUnknown; hint <= HintUniform; hint++ {
+		testAppendedWithHint(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4, hint)
+		testAppendedWithHint(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4, hint)
+		testAppendedWithHint(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 14, 12, 8, 1}, 4, hint)
+		testAppendedWithHint(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 1, 8, 12, 14}, 4, hint)
+	}
+}
+
+func TestAppendedWithHintThreshold(t *testing.T) {
+	// 2100^2 > 64*65536, but 1890^2 < 64*65536
+	const totalSize, tailSize = 65536, 2100
+	s := make(intSlice, totalSize)
+	for idx := range s {
+		s[idx] = idx % (totalSize - tailSize)
+	}
+	stdsort.Ints(s[:totalSize-tailSize])
+	for hint, expected := range map[AppendedHint]string{
+		HintUnknown:       StrategyFallbackSort,
+		HintNearlySorted:  StrategyGroupInsert,
+		HintReverseSorted: StrategyGroupInsert,
+		HintUniform:       StrategyFallbackSort,
+	} {
+		c := make(intSlice, totalSize)
+		copy(c, s)
+		var strategy string
+		OnStrategy = func(_strategy string, _, _ uint) {
+			strategy = _strategy
+		}
+		AppendedWithHint(c, tailSize, hint)
+		OnStrategy = nil
+		if strategy != expected {
+			t.Errorf("%s: %v != %v", hint, strategy, expected)
+		}
+		if !stdsort.IntsAreSorted(c) {
+			t.Errorf("%s: not sorted", hint)
+		}
+	}
+
+	// never more permissive than Appended by more than the discount
+	for totalSize := uint(1); totalSize < 4096; totalSize++ {
+		for tailSize := uint(0); tailSize <= totalSize; tailSize++ {
+			for hint := HintUnknown; hint <= HintUniform; hint++ {
+				if appendedHintPolicy(hint).shouldUseAppended(totalSize, tailSize) && !shouldUseAppended(totalSize, tailSize*9/10) {
+					t.Fatalf("%s: %d/%d", hint, totalSize, tailSize)
+				}
+			}
+		}
+	}
+}
+
+func FuzzAppendedWithHint(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		initial = append([]byte{}, initial...)
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		hint := AppendedHint(rand.Intn(int(HintUniform) + 1))
+		tail := make([]int, tailLenght)
+		for idx, v := range initial[len(initial)-int(tailLenght):] {
+			tail[idx] = int(v)
+		}
+		arrangeTail(rand.New(rand.NewSource(0)), tail, AppendedHint(rand.Intn(int(HintUniform)+1)))
+		for idx, v := range tail {
+			initial[len(initial)-int(tailLenght)+idx] = byte(v)
+		}
+		testAppendedWithHint(t, initial, tailLenght, hint)
+	})
+}
+
+func BenchmarkAppendedWithHint(b *testing.B) {
+	const (
+		totalSize = 65536
+		tailSize  = 1024
+		csCount   = 20
+	)
+	for distribution := HintNearlySorted; distribution <= HintUniform; distribution++ {
+		rng := rand.New(rand.NewSource(0))
+		in := make([][]int, csCount)
+		for idx := range in {
+			in[idx] = make([]int, totalSize)
+			s := in[idx]
+			for idx := range s {
+				s[idx] = rng.Intn(totalSize)
+			}
+			stdsort.Ints(s[:totalSize-tailSize])
+			arrangeTail(rng, s[totalSize-tailSize:], distribution)
+		}
+
+		cs := make([]intSlice, csCount)
+		for idx := range cs {
+			cs[idx] = make([]int, totalSize)
+		}
+
+		for hint := HintUnknown; hint <= HintUniform; hint++ {
+			b.Run(fmt.Sprintf("total-%d/tail-%d/distribution-%s/hint-%s", totalSize, tailSize, distribution, hint), func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range cs {
+							copy(cs[idx], in[idx])
+						}
+						b.StartTimer()
+					}
+					AppendedWithHint(cs[idx], tailSize, hint)
+				}
+			})
+		}
+	}
+}
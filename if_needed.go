@@ -0,0 +1,32 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+// AppendedIfNeeded is the same as Appended, but it first checks if
+// the slice is already sorted (in which case the slice is not touched).
+// Since the prefix is assumed to be sorted, only the junction and the tail
+// are checked, which is cheap for a small tail.
+//
+// It returns true if the slice was sorted, and false if it was already
+// in order.
+//
+// T: O(k) [if already sorted], otherwise the same as Appended
+//
+// S: O(1) [if without `s`]
+func AppendedIfNeeded[E any, S Interface[E]](s S, tailLength uint) bool {
+	checkTailLength(len(s), tailLength)
+	splitIdx := len(s) - int(tailLength)
+	startIdx := splitIdx
+	if startIdx == 0 {
+		startIdx = 1
+	}
+	for idx := startIdx; idx < len(s); idx++ {
+		if s.Less(idx, idx-1) {
+			Appended(s, tailLength)
+			return true
+		}
+	}
+	return false
+}
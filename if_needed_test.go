@@ -0,0 +1,101 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+func testAppendedIfNeeded(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		wasSorted := stdsort.IntsAreSorted(s)
+		sorted := AppendedIfNeeded(intSlice(s), tailLenght)
+		if sorted == wasSorted {
+			t.Fatalf("unexpected result: %t; testCase < %s , %s >", sorted, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedIfNeeded(t *testing.T) {
+	testAppendedIfNeeded(t, []byte{}, 0)
+	testAppendedIfNeeded(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedIfNeeded(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedIfNeeded(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 15, 16, 18, 20}, 4)
+	testAppendedIfNeeded(t, []byte{1, 2, 3}, 3)
+	testAppendedIfNeeded(t, []byte{3, 2, 1}, 3)
+	testAppendedIfNeeded(t, []byte{1, 2, 3}, 0)
+
+	t.Run("untouched", func(t *testing.T) {
+		s, lessCalls := newCountedInts([]int{1, 2, 3, 4, 5, 6})
+		if AppendedIfNeeded(s, 2) {
+			t.Fatal("expected false")
+		}
+		if *lessCalls != 2 {
+			t.Fatalf("expected to check only the junction and the tail, got %d comparisons", *lessCalls)
+		}
+	})
+}
+
+func FuzzAppendedIfNeeded(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedIfNeeded(t, initial, tailLenght)
+	})
+}
+
+func BenchmarkAppendedIfNeeded(b *testing.B) {
+	const (
+		totalSize = 65536
+		csCount   = 20
+	)
+	for _, tailSize := range []int{16, 1024} {
+		in := make([]int, totalSize)
+		for idx := range in {
+			in[idx] = idx
+		}
+
+		cs := make([]intSlice, csCount)
+		for idx := range cs {
+			cs[idx] = make([]int, totalSize)
+		}
+
+		for _, f := range []struct {
+			name string
+			fn   func(intSlice, uint)
+		}{
+			{name: "Appended", fn: Appended[int, intSlice]},
+			{name: "AppendedIfNeeded", fn: func(s intSlice, tailLength uint) {
+				AppendedIfNeeded(s, tailLength)
+			}},
+		} {
+			b.Run(fmt.Sprintf("total-%d/tail-%d/sorted/%s", totalSize, tailSize, f.name), func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range cs {
+							copy(cs[idx], in)
+						}
+						b.StartTimer()
+					}
+					f.fn(cs[idx], uint(tailSize))
+				}
+			})
+		}
+	}
+}
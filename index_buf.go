@@ -0,0 +1,107 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	stdsort "sort"
+
+	"github.com/go-ng/sort"
+)
+
+// AppendedWithIndexBuf is the same as AppendedWithBuf, but it buffers
+// indices instead of elements, which is useful for big elements (like
+// large structs): the buffer takes 2*k ints instead of k elements, and
+// the elements are moved directly to their final positions (following
+// the cycles of the permutation), so each moved element is copied only
+// once (plus one copy per cycle) and the tail elements are never copied
+// to a buffer and back.
+//
+// However the elements are moved in a non-sequential order (unlike
+// the plain memory moves of AppendedWithBuf), so it is usually slower
+// than AppendedWithBuf (see BenchmarkAppendedWithIndexBuf). Prefer it
+// when the memory for an element buffer matters.
+//
+// The length of idxBuf should be at least 2*tailLength.
+//
+// T: O(k*ln(n) + n*ln(k))
+//
+// S: O(k) [if without `s`]
+func AppendedWithIndexBuf[E any, S Interface[E]](s S, tailLength uint, idxBuf []int) {
+	checkTailLength(len(s), tailLength)
+	if uint(len(idxBuf)) < 2*tailLength {
+		panic(fmt.Sprintf("the index buffer is too small: %d < 2*%d", len(idxBuf), tailLength))
+	}
+	strategy := startAppended(s, tailLength, appendedPolicy{shouldUse: shouldUseAppendedWithBuf})
+	if strategy != StrategyGroupInsert {
+		finishAppended(s, tailLength, strategy)
+		return
+	}
+	splitIdx := len(s) - int(tailLength)
+
+	// Strategy:
+	//
+	// 1. Sort the offsets of the tail elements (instead of the elements).
+	// 2. Find the final position of each tail element: its insertion point
+	//    in the prefix plus its rank in the tail.
+	// 3. Move each element directly to its final position. The prefix
+	//    elements are only moved to the right and the tail elements are
+	//    only moved to the left, thus each cycle of the permutation contains
+	//    a tail element: so it is enough to follow the cycles starting
+	//    from the tail elements.
+
+	tailOffsets := idxBuf[:tailLength]
+	for idx := range tailOffsets {
+		tailOffsets[idx] = idx
+	}
+	sort.Slice(tailOffsets, func(i, j int) bool {
+		return s.Less(splitIdx+tailOffsets[i], splitIdx+tailOffsets[j])
+	})
+
+	destinations := idxBuf[tailLength : 2*tailLength]
+	insertIdx := 0
+	for rank, offset := range tailOffsets {
+		insertIdx += sort.Search(splitIdx-insertIdx, func(i int) bool {
+			return s.Less(splitIdx+offset, insertIdx+i)
+		})
+		destinations[rank] = insertIdx + rank
+	}
+
+	for _, offset := range tailOffsets {
+		if offset < 0 {
+			// already visited
+			continue
+		}
+		startIdx := splitIdx + offset
+		tmp := s[startIdx]
+		pos := startIdx
+		// rank is the amount of the destinations of the tail elements,
+		// which are less than pos
+		rank := stdsort.SearchInts(destinations, pos)
+		for {
+			var src int
+			if rank < len(destinations) && destinations[rank] == pos {
+				// a tail element goes here
+				tailOffsets[rank] = ^tailOffsets[rank]
+				src = splitIdx + ^tailOffsets[rank]
+				if src != startIdx {
+					rank = stdsort.SearchInts(destinations, src)
+				}
+			} else {
+				// a prefix element goes here, it is shifted by rank
+				src = pos - rank
+				if rank > 0 && destinations[rank-1] >= src {
+					rank = stdsort.SearchInts(destinations[:rank], src)
+				}
+			}
+			if src == startIdx {
+				s[pos] = tmp
+				break
+			}
+			s[pos] = s[src]
+			pos = src
+		}
+	}
+}
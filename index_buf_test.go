@@ -0,0 +1,111 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+func testAppendedWithIndexBuf(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		AppendedWithIndexBuf(intSlice(s), tailLenght, make([]int, 2*tailLenght))
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedWithIndexBuf(t *testing.T) {
+	testAppendedWithIndexBuf(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedWithIndexBuf(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedWithIndexBuf(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 14, 12, 8, 1}, 4)
+	testAppendedWithIndexBuf(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 1, 8, 12, 14}, 4)
+	testAppendedWithIndexBuf(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 16, 17, 18, 19}, 4)
+
+	t.Run("small_buffer", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		AppendedWithIndexBuf(intSlice{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 1}, 1, make([]int, 1))
+	})
+}
+
+func FuzzAppendedWithIndexBuf(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedWithIndexBuf(t, initial, tailLenght)
+	})
+}
+
+type bigElem struct {
+	Key     int64
+	Payload [15]int64
+}
+
+type bigElems []bigElem
+
+func (s bigElems) Less(i, j int) bool {
+	return s[i].Key < s[j].Key
+}
+
+func BenchmarkAppendedWithIndexBuf(b *testing.B) {
+	const (
+		totalSize = 65536
+		csCount   = 10
+	)
+	for _, tailSize := range []int{16, 256, 4096} {
+		rng := rand.New(rand.NewSource(0))
+		in := make([]bigElems, csCount)
+		for idx := range in {
+			in[idx] = make(bigElems, totalSize)
+			s := in[idx]
+			for idx := range s {
+				s[idx].Key = int64(rng.Intn(totalSize))
+			}
+			stdsort.Sort(stdInterface[bigElem, bigElems](s[:totalSize-tailSize]))
+		}
+
+		cs := make([]bigElems, csCount)
+		for idx := range cs {
+			cs[idx] = make(bigElems, totalSize)
+		}
+
+		buf := make([]bigElem, tailSize)
+		idxBuf := make([]int, 2*tailSize)
+		for _, f := range []struct {
+			name string
+			fn   func(bigElems)
+		}{
+			{name: "AppendedWithBuf", fn: func(s bigElems) { AppendedWithBuf(s, buf) }},
+			{name: "AppendedWithIndexBuf", fn: func(s bigElems) { AppendedWithIndexBuf(s, uint(tailSize), idxBuf) }},
+		} {
+			b.Run(fmt.Sprintf("total-%d/tail-%d/%s", totalSize, tailSize, f.name), func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range cs {
+							copy(cs[idx], in[idx])
+						}
+						b.StartTimer()
+					}
+					f.fn(cs[idx])
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"github.com/go-ng/sort"
+)
+
+// SortedInsert appends v to the sorted slice s (growing it if required)
+// and moves it into its place, so the result is also sorted. The resulting
+// slice is returned (like `append` does).
+//
+// T: O(ln(n) + n)
+//
+// S: O(1) [if without `s`]
+func SortedInsert[E any, S Interface[E]](s S, v E) S {
+	s = append(s, v)
+	BinaryInsert(s)
+	return s
+}
+
+// BinaryInsert moves the last element of the slice into its place,
+// assuming `s[:len(s)-1]` is already sorted. The element is placed after
+// all the elements equal to it.
+//
+// It is the same as `Appended(s, 1)`, but simpler and faster: it is just
+// a binary search and a single move of the greater elements.
+//
+// T: O(ln(n) + n)
+//
+// S: O(1) [if without `s`]
+func BinaryInsert[E any, S Interface[E]](s S) {
+	if len(s) < 2 {
+		return
+	}
+	lastIdx := len(s) - 1
+	insertIdx := sort.Search(lastIdx, func(i int) bool {
+		return s.Less(lastIdx, i)
+	})
+	if insertIdx == lastIdx {
+		return
+	}
+	v := s[lastIdx]
+	copy(s[insertIdx+1:], s[insertIdx:lastIdx])
+	s[insertIdx] = v
+}
+
+// SortedInsertMany appends vs to the sorted slice s (growing it if required)
+// and sorts the result using Appended. The resulting slice is returned
+// (like `append` does).
+func SortedInsertMany[E any, S Interface[E]](s S, vs []E) S {
+	s = append(s, vs...)
+	Appended(s, uint(len(vs)))
+	return s
+}
+
+// SortedAppend appends vs to the sorted slice s (growing it if required)
+// and sorts the result the same way as AppendedFunc (but with a less
+// function). The resulting slice is returned (like `append` does).
+//
+// T: the same as Appended
+//
+// S: O(1) [if without `s`]
+func SortedAppend[E any, S ~[]E](s S, less func(a, b E) bool, vs ...E) S {
+	s = append(s, vs...)
+	appendedLessFunc([]E(s), uint(len(vs)), less)
+	return s
+}
@@ -0,0 +1,166 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"testing"
+)
+
+func TestSortedInsert(t *testing.T) {
+	var s intSlice
+	var expected []int
+	rng := rand.New(rand.NewSource(0))
+	for i := 0; i < 100; i++ {
+		v := rng.Intn(30)
+		s = SortedInsert(s, v)
+		expected = append(expected, v)
+		stdsort.Ints(expected)
+		if !intsEqual(expected, s) {
+			t.Fatalf("%v != %v", expected, s)
+		}
+	}
+}
+
+func TestSortedInsertMany(t *testing.T) {
+	var s intSlice
+	var expected []int
+	rng := rand.New(rand.NewSource(0))
+	for i := 0; i < 100; i++ {
+		vs := make([]int, rng.Intn(10))
+		for idx := range vs {
+			vs[idx] = rng.Intn(30)
+		}
+		s = SortedInsertMany(s, vs)
+		expected = append(expected, vs...)
+		stdsort.Ints(expected)
+		if !intsEqual(expected, s) {
+			t.Fatalf("%v != %v", expected, s)
+		}
+	}
+}
+
+func TestBinaryInsert(t *testing.T) {
+	for _, testCase := range []struct {
+		s        keySeqs
+		expected []int
+	}{
+		{s: keySeqs{}, expected: []int{}},
+		{s: keySeqs{{1, 0}}, expected: []int{0}},
+		// at the front
+		{s: keySeqs{{1, 0}, {2, 1}, {3, 2}, {0, 3}}, expected: []int{3, 0, 1, 2}},
+		// at the back
+		{s: keySeqs{{1, 0}, {2, 1}, {3, 2}, {4, 3}}, expected: []int{0, 1, 2, 3}},
+		// in the middle
+		{s: keySeqs{{1, 0}, {2, 1}, {4, 2}, {3, 3}}, expected: []int{0, 1, 3, 2}},
+		// equal to existing elements: after them
+		{s: keySeqs{{1, 0}, {2, 1}, {2, 2}, {3, 3}, {2, 4}}, expected: []int{0, 1, 2, 4, 3}},
+		{s: keySeqs{{1, 0}, {1, 1}}, expected: []int{0, 1}},
+	} {
+		BinaryInsert(testCase.s)
+		for idx, v := range testCase.s {
+			if v.Seq != testCase.expected[idx] {
+				t.Fatalf("%v != %v", testCase.s, testCase.expected)
+			}
+		}
+	}
+}
+
+func FuzzBinaryInsert(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		s, _, _, _ := prepareTestCase(initial, uint(min(len(initial), 1)))
+		c := make([]int, len(s))
+		copy(c, s)
+		BinaryInsert(intSlice(s))
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v", c, s)
+		}
+	})
+}
+
+func BenchmarkBinaryInsert(b *testing.B) {
+	const (
+		totalSize = 65536
+		csCount   = 20
+	)
+	rng := rand.New(rand.NewSource(0))
+	in := make([][]int, csCount)
+	for idx := range in {
+		in[idx] = make([]int, totalSize)
+		s := in[idx]
+		for idx := range s {
+			s[idx] = rng.Intn(totalSize)
+		}
+		stdsort.Ints(s[:totalSize-1])
+	}
+
+	cs := make([]intSlice, csCount)
+	for idx := range cs {
+		cs[idx] = make([]int, totalSize)
+	}
+
+	for _, f := range []struct {
+		name string
+		fn   func(intSlice)
+	}{
+		{name: "Appended", fn: func(s intSlice) { Appended(s, 1) }},
+		{name: "BinaryInsert", fn: BinaryInsert[int, intSlice]},
+	} {
+		b.Run(fmt.Sprintf("total-%d/%s", totalSize, f.name), func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				idx := i % csCount
+				if idx == 0 {
+					b.StopTimer()
+					for idx := range cs {
+						copy(cs[idx], in[idx])
+					}
+					b.StartTimer()
+				}
+				f.fn(cs[idx])
+			}
+		})
+	}
+}
+
+func TestSortedAppend(t *testing.T) {
+	less := func(a, b int) bool {
+		return a < b
+	}
+	type ints []int
+
+	s := make(ints, 0, 4)
+	s = SortedAppend(s, less)
+	if len(s) != 0 {
+		t.Fatalf("expected an empty slice: %v", s)
+	}
+	s = SortedAppend(s, less, 5)
+	s = SortedAppend(s, less, 3)
+	s = SortedAppend(s, less, 4, 1)
+	if !intsEqual(s, []int{1, 3, 4, 5}) || cap(s) != 4 {
+		t.Fatalf("unexpected result: %v (cap: %d)", s, cap(s))
+	}
+
+	// exceeds the capacity
+	var expected []int
+	expected = append(expected, s...)
+	rng := rand.New(rand.NewSource(0))
+	for i := 0; i < 100; i++ {
+		vs := make([]int, rng.Intn(50))
+		for idx := range vs {
+			vs[idx] = rng.Intn(100)
+		}
+		s = SortedAppend(s, less, vs...)
+		expected = append(expected, vs...)
+		stdsort.Ints(expected)
+		if !intsEqual(expected, s) {
+			t.Fatalf("%v != %v", expected, s)
+		}
+	}
+}
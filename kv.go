@@ -0,0 +1,68 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"cmp"
+	"fmt"
+	stdsort "sort"
+
+	"github.com/go-ng/slices"
+)
+
+// AppendedKV is the same as Appended, but it sorts keys, and permutes
+// values (a parallel slice) in lockstep: `values[i]` stays paired with
+// `keys[i]`. It avoids packing keys and values into structs just to sort
+// them. It shares the implementation with Appended.
+//
+// It panics if the lengths of keys and values differ.
+//
+// T: O(k*ln(n) + n + k^2) -- thus if `k` is too high then: O(n*ln(n))
+//
+// S: O(1) [if without `keys` and `values`]
+func AppendedKV[K cmp.Ordered, V any](keys []K, values []V, tailLength uint) {
+	if len(keys) != len(values) {
+		panic(fmt.Sprintf("the lengths of keys (%d) and values (%d) differ", len(keys), len(values)))
+	}
+	appendedSeq(kvSlice[K, V]{keys: keys, values: values}, tailLength)
+}
+
+// kvSlice implements the standard `sort.Interface` and sequence for
+// parallel slices of keys and values, ordered by keys.
+type kvSlice[K cmp.Ordered, V any] struct {
+	keys   []K
+	values []V
+}
+
+func (s kvSlice[K, V]) Len() int {
+	return len(s.keys)
+}
+
+func (s kvSlice[K, V]) Less(i, j int) bool {
+	return cmp.Less(s.keys[i], s.keys[j])
+}
+
+func (s kvSlice[K, V]) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+}
+
+func (s kvSlice[K, V]) Rotate(a, b, shift int) {
+	slices.Rotate(s.keys[a:b], shift)
+	slices.Rotate(s.values[a:b], shift)
+}
+
+func (s kvSlice[K, V]) Reverse(a, b int) {
+	slices.Reverse(s.keys[a:b])
+	slices.Reverse(s.values[a:b])
+}
+
+func (s kvSlice[K, V]) Sort(a, b int) {
+	stdsort.Sort(kvSlice[K, V]{keys: s.keys[a:b], values: s.values[a:b]})
+}
+
+func (s kvSlice[K, V]) SortDescending(a, b int) {
+	stdsort.Sort(stdsort.Reverse(kvSlice[K, V]{keys: s.keys[a:b], values: s.values[a:b]}))
+}
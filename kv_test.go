@@ -0,0 +1,158 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+func testAppendedKV(t *testing.T, initial []byte, tailLenght uint) {
+	keys, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	origKeys := append([]int{}, keys...)
+	// values are the original indexes, thus the result may be checked
+	// against the reference sorting (key, index) pairs
+	values := make([]int, len(keys))
+	for idx := range values {
+		values[idx] = idx
+	}
+	t.Run(testName, func(t *testing.T) {
+		AppendedKV(keys, values, tailLenght)
+
+		expected := make([]int, len(keys))
+		copy(expected, origKeys)
+		stdsort.Ints(expected)
+		if !intsEqual(expected, keys) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", expected, keys, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+		seen := make([]bool, len(values))
+		for idx, origIdx := range values {
+			if seen[origIdx] {
+				t.Fatalf("value %d is duplicated: %v", origIdx, values)
+			}
+			seen[origIdx] = true
+			if origKeys[origIdx] != keys[idx] {
+				t.Fatalf("value %d is not paired with its key: %d != %d", origIdx, origKeys[origIdx], keys[idx])
+			}
+		}
+	})
+}
+
+func TestAppendedKV(t *testing.T) {
+	testAppendedKV(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedKV(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedKV(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 14, 12, 8, 1}, 4)
+	testAppendedKV(t, []byte{5, 4, 3, 2, 1}, 5)
+
+	t.Run("strings", func(t *testing.T) {
+		keys := []string{"a", "c", "e", "g", "i", "k", "m", "o", "q", "s", "u", "w", "y", "z", "b", "d"}
+		values := []rune("aceg" + "ikmo" + "qsuw" + "yzbd")
+		AppendedKV(keys, values, 2)
+		for idx := range keys {
+			if keys[idx] != string(values[idx]) {
+				t.Fatalf("not paired at %d: %v %q", idx, keys, string(values))
+			}
+		}
+		if !stdsort.StringsAreSorted(keys) {
+			t.Fatalf("not sorted: %v", keys)
+		}
+	})
+
+	t.Run("different_lengths", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		AppendedKV([]int{1, 2, 3}, []int{1, 2}, 1)
+	})
+}
+
+func FuzzAppendedKV(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedKV(t, initial, tailLenght)
+	})
+}
+
+func BenchmarkAppendedKV(b *testing.B) {
+	const (
+		totalSize = 65536
+		csCount   = 20
+	)
+	type kv struct {
+		key   int
+		value int
+	}
+	for _, tailSize := range []int{16, 1024} {
+		rng := rand.New(rand.NewSource(0))
+		inKeys := make([][]int, csCount)
+		for idx := range inKeys {
+			inKeys[idx] = make([]int, totalSize)
+			s := inKeys[idx]
+			for idx := range s {
+				s[idx] = rng.Intn(totalSize)
+			}
+			stdsort.Ints(s[:totalSize-tailSize])
+		}
+
+		keys := make([][]int, csCount)
+		values := make([][]int, csCount)
+		kvs := make([][]kv, csCount)
+		for idx := range keys {
+			keys[idx] = make([]int, totalSize)
+			values[idx] = make([]int, totalSize)
+			kvs[idx] = make([]kv, totalSize)
+		}
+
+		for _, f := range []struct {
+			name  string
+			reset func(idx int)
+			fn    func(idx int)
+		}{
+			{
+				name: "AppendedFunc-structs",
+				reset: func(idx int) {
+					for i, k := range inKeys[idx] {
+						kvs[idx][i] = kv{key: k}
+					}
+				},
+				fn: func(idx int) {
+					AppendedFunc(kvs[idx], uint(tailSize), func(a, b kv) int {
+						return a.key - b.key
+					})
+				},
+			},
+			{
+				name: "AppendedKV",
+				reset: func(idx int) {
+					copy(keys[idx], inKeys[idx])
+				},
+				fn: func(idx int) {
+					AppendedKV(keys[idx], values[idx], uint(tailSize))
+				},
+			},
+		} {
+			b.Run(fmt.Sprintf("total-%d/tail-%d/%s", totalSize, tailSize, f.name), func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range keys {
+							f.reset(idx)
+						}
+						b.StartTimer()
+					}
+					f.fn(idx)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"sync"
+)
+
+// AppendedLocked is a sorted slice (see Sorted) protected by a mutex, so
+// it may be safely shared by multiple goroutines (for example, by request
+// handlers of a server).
+//
+// The functions of this package do not synchronize anything: calling
+// Appended concurrently on overlapping slices silently corrupts them.
+// AppendedLocked provides a safe pattern for that case.
+//
+// The zero value is an empty sorted slice ready to use. It must not be
+// copied after the first use.
+type AppendedLocked[E any, S Interface[E]] struct {
+	mu     sync.Mutex
+	sorted Sorted[E, S]
+}
+
+// NewAppendedLocked returns an AppendedLocked using s as the initial
+// content (s is sorted in-place, and it is owned by the returned
+// AppendedLocked).
+func NewAppendedLocked[E any, S Interface[E]](s S) *AppendedLocked[E, S] {
+	return &AppendedLocked[E, S]{
+		sorted: *NewSorted(s),
+	}
+}
+
+// Insert inserts all the vs keeping the slice sorted (see
+// Sorted.BulkInsert).
+func (l *AppendedLocked[E, S]) Insert(vs ...E) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sorted.BulkInsert(vs)
+}
+
+// Do calls fn with the sorted slice while holding the lock.
+//
+// fn may modify the elements, but it must keep the slice sorted, and it
+// must not retain the slice after returning.
+func (l *AppendedLocked[E, S]) Do(fn func(s S)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fn(l.sorted.Slice())
+}
@@ -0,0 +1,97 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"sync"
+	"testing"
+)
+
+func TestAppendedLocked(t *testing.T) {
+	t.Run("zero_value", func(t *testing.T) {
+		var l AppendedLocked[int, intSlice]
+		l.Insert(3, 1, 2)
+		l.Do(func(s intSlice) {
+			if !Equal(s, []int{1, 2, 3}) {
+				t.Fatalf("unexpected content: %v", s)
+			}
+		})
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		// Run with -race to make sure the access is synchronized.
+		const (
+			writers        = 8
+			batches        = 50
+			batchLength    = 10
+			initialLength  = 100
+			expectedLength = initialLength + writers*batches*batchLength
+		)
+		initial := make(intSlice, initialLength)
+		for idx := range initial {
+			initial[idx] = rand.Intn(1000)
+		}
+		l := NewAppendedLocked(initial)
+
+		var wg sync.WaitGroup
+		for writer := 0; writer < writers; writer++ {
+			wg.Add(2)
+			go func(seed int64) {
+				defer wg.Done()
+				rng := rand.New(rand.NewSource(seed))
+				for batch := 0; batch < batches; batch++ {
+					vs := make([]int, batchLength)
+					for idx := range vs {
+						vs[idx] = rng.Intn(1000)
+					}
+					l.Insert(vs...)
+				}
+			}(int64(writer))
+			go func() {
+				defer wg.Done()
+				for batch := 0; batch < batches; batch++ {
+					l.Do(func(s intSlice) {
+						if !stdsort.IntsAreSorted(s) {
+							panic(fmt.Sprintf("not sorted: %v", s))
+						}
+					})
+				}
+			}()
+		}
+		wg.Wait()
+
+		l.Do(func(s intSlice) {
+			if len(s) != expectedLength {
+				t.Fatalf("unexpected length: %d != %d", len(s), expectedLength)
+			}
+			if !stdsort.IntsAreSorted(s) {
+				t.Fatalf("not sorted: %v", s)
+			}
+		})
+	})
+}
+
+func BenchmarkAppendedLocked(b *testing.B) {
+	initial := make(intSlice, 65536)
+	for idx := range initial {
+		initial[idx] = rand.Intn(len(initial))
+	}
+	for _, batchLength := range []int{1, 16} {
+		b.Run(fmt.Sprintf("total-%d/batch-%d", len(initial), batchLength), func(b *testing.B) {
+			l := NewAppendedLocked(append(intSlice{}, initial...))
+			vs := make([]int, batchLength)
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					l.Insert(vs...)
+				}
+			})
+		})
+	}
+}
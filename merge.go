@@ -0,0 +1,127 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+)
+
+// Merge merges the sorted slices left and right into dst. The merge is
+// stable: if elements of left and right are equal, then the elements of
+// left go first.
+//
+// The length of dst should be exactly len(left)+len(right), and dst should
+// not overlap with left or right. The elements are compared using dst.Less,
+// thus the compared elements are temporary placed into dst.
+//
+// T: O(n)
+//
+// S: O(1)
+func Merge[E any, S Interface[E]](dst S, left, right []E) {
+	if len(dst) != len(left)+len(right) {
+		panic(fmt.Sprintf("the length of dst (%d) should be equal to the sum of the lengths of left and right (%d + %d)", len(dst), len(left), len(right)))
+	}
+
+	outIdx, leftIdx, rightIdx := 0, 0, 0
+	for leftIdx < len(left) && rightIdx < len(right) {
+		// There are at least two free slots in dst here.
+		dst[outIdx] = right[rightIdx]
+		dst[outIdx+1] = left[leftIdx]
+		if dst.Less(outIdx, outIdx+1) {
+			rightIdx++
+		} else {
+			dst[outIdx] = left[leftIdx]
+			leftIdx++
+		}
+		outIdx++
+	}
+	outIdx += copy(dst[outIdx:], left[leftIdx:])
+	copy(dst[outIdx:], right[rightIdx:])
+}
+
+// MergeSortedNew returns a newly allocated sorted slice, which contains
+// the elements of the sorted slices a and b. The inputs are not modified.
+// If elements of a and b are equal, then the elements of a go first.
+//
+// If b is much shorter than a, then b is inserted into a copy of a
+// the same way as in Appended (which requires less comparisons),
+// otherwise the slices are merged linearly.
+//
+// T: O(n) [O(n + k*ln(n)) if b is short]
+//
+// S: O(n)
+func MergeSortedNew[E any](a, b []E, less func(a, b E) bool) []E {
+	totalSize := len(a) + len(b)
+	if len(b) <= len(a) && !isBelowMinAppendedSize(uint(totalSize)) && shouldUseAppended(uint(totalSize), uint(len(b))) {
+		result := make([]E, 0, totalSize)
+		result = append(result, a...)
+		result = append(result, b...)
+		groupInsertAppendSortSeq(funcSeq[E]{s: result, less: less}, uint(len(b)))
+		return result
+	}
+
+	result := make([]E, 0, totalSize)
+	aIdx, bIdx := 0, 0
+	for aIdx < len(a) && bIdx < len(b) {
+		if less(b[bIdx], a[aIdx]) {
+			result = append(result, b[bIdx])
+			bIdx++
+		} else {
+			result = append(result, a[aIdx])
+			aIdx++
+		}
+	}
+	result = append(result, a[aIdx:]...)
+	return append(result, b[bIdx:]...)
+}
+
+// mergeStreamMaxTailDivisor defines the maximal streamed part inserted by
+// MergeStream the same way as in Appended: the resulting length divided
+// by mergeStreamMaxTailDivisor. The streamed elements are already sorted, so the linear
+// merge is cheaper than for Appended and the threshold is lower than
+// the one of shouldUseAppended.
+const mergeStreamMaxTailDivisor = 256
+
+// MergeStream merges the elements yielded by next (in sorted order, until
+// it returns false) into the sorted slice dst (growing it if required).
+// The resulting slice is returned (like `append` does). If elements
+// of dst and of the stream are equal, then the elements of dst go first.
+//
+// If the stream is much shorter than dst, then the streamed elements are
+// inserted the same way as in Appended, otherwise they are merged linearly
+// (using a temporary buffer for the streamed elements).
+//
+// T: O(n) [O(n + k*ln(n)) if the stream is short]
+//
+// S: O(k)
+func MergeStream[E any](dst []E, next func() (E, bool), less func(a, b E) bool) []E {
+	prefixLength := len(dst)
+	for v, ok := next(); ok; v, ok = next() {
+		dst = append(dst, v)
+	}
+	tailLength := len(dst) - prefixLength
+	if tailLength == 0 || prefixLength == 0 || !less(dst[prefixLength], dst[prefixLength-1]) {
+		return dst
+	}
+
+	if tailLength <= len(dst)/mergeStreamMaxTailDivisor && shouldUseAppended(uint(len(dst)), uint(tailLength)) {
+		groupInsertAppendSortSeq(funcSeq[E]{s: dst, less: less}, uint(tailLength))
+		return dst
+	}
+
+	buf := make([]E, tailLength)
+	copy(buf, dst[prefixLength:])
+	dstIdx, bufIdx := prefixLength-1, tailLength-1
+	for outIdx := len(dst) - 1; bufIdx >= 0; outIdx-- {
+		if dstIdx >= 0 && less(buf[bufIdx], dst[dstIdx]) {
+			dst[outIdx] = dst[dstIdx]
+			dstIdx--
+		} else {
+			dst[outIdx] = buf[bufIdx]
+			bufIdx--
+		}
+	}
+	return dst
+}
@@ -0,0 +1,67 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	stdslices "slices"
+)
+
+// Integer is a constraint of integer types.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// AppendedMergeBranchless is the same as AppendedWithBuf, but for
+// integers. It sorts the tail and merges it into the prefix from the end,
+// using a loop, which selects the greater value by a simple conditional
+// assignment and advances the indexes arithmetically, instead of
+// branching into two different bodies. It allows the compiler to avoid
+// mispredicted branches on random data (see
+// BenchmarkAppendedMergeBranchless for a comparison with a usual merge
+// loop).
+//
+// Unlike AppendedWithBuf it never falls back to a full sort, so it is
+// also efficient for big tails.
+//
+// T: O(k*ln(k) + n)
+//
+// S: O(k) [if without `s`]
+func AppendedMergeBranchless[E Integer](s []E, buf []E) {
+	tailLength := uint(len(buf))
+	switch startAppended(OrderedAsc[E](s), tailLength, appendedPolicy{shouldUse: alwaysUseAppended}) {
+	case StrategyFallbackSort:
+		stdslices.Sort(s)
+	case StrategySortTail:
+		stdslices.Sort(s[uint(len(s))-tailLength:])
+	case StrategyGroupInsert:
+		splitIdx := len(s) - len(buf)
+		tail := s[splitIdx:]
+		stdslices.Sort(tail)
+		copy(buf, tail)
+		mergeIntsBackwardBranchless(s, splitIdx, buf)
+	}
+}
+
+// mergeIntsBackwardBranchless merges the sorted s[:splitIdx] and
+// the sorted buf into s (of length splitIdx+len(buf)), starting from
+// the biggest elements. The only data-dependent condition of the body of
+// the loop is a simple conditional assignment.
+func mergeIntsBackwardBranchless[E Integer](s []E, splitIdx int, buf []E) {
+	leftIdx, rightIdx := splitIdx-1, len(buf)-1
+	for outIdx := len(s) - 1; leftIdx >= 0 && rightIdx >= 0; outIdx-- {
+		left, right := s[leftIdx], buf[rightIdx]
+		takeLeft := 0
+		v := right
+		if left > right {
+			takeLeft = 1
+			v = left
+		}
+		s[outIdx] = v
+		leftIdx -= takeLeft
+		rightIdx -= 1 - takeLeft
+	}
+	copy(s, buf[:rightIdx+1])
+}
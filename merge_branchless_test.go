@@ -0,0 +1,158 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+func testAppendedMergeBranchless(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	s64 := make([]int64, len(s))
+	for idx, v := range s {
+		s64[idx] = int64(v)
+	}
+	c := make([]int, len(s))
+	copy(c, s)
+	stdsort.Ints(c)
+	t.Run(testName, func(t *testing.T) {
+		for _, merge := range []struct {
+			name string
+			fn   func(s []int64, splitIdx int, buf []int64)
+		}{
+			{name: "default"},
+			{name: "branchless", fn: mergeIntsBackwardBranchless[int64]},
+			{name: "usual", fn: mergeIntsBackward[int64]},
+		} {
+			s := append([]int64{}, s64...)
+			if merge.fn == nil {
+				AppendedMergeBranchless(s, make([]int64, tailLenght))
+			} else {
+				splitIdx := len(s) - int(tailLenght)
+				stdsort.Slice(s[splitIdx:], func(i, j int) bool {
+					return s[splitIdx+i] < s[splitIdx+j]
+				})
+				buf := append([]int64{}, s[splitIdx:]...)
+				merge.fn(s, splitIdx, buf)
+			}
+			for idx := range c {
+				if int64(c[idx]) != s[idx] {
+					t.Fatalf("%s: %v != %v; testCase < %s , %s >", merge.name, c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+				}
+			}
+		}
+	})
+}
+
+func TestAppendedMergeBranchless(t *testing.T) {
+	testAppendedMergeBranchless(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedMergeBranchless(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedMergeBranchless(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 16, 18, 20, 21}, 4)
+	testAppendedMergeBranchless(t, []byte{5, 4, 3, 2, 1}, 5)
+
+	t.Run("uint8", func(t *testing.T) {
+		s := []uint8{1, 5, 9, 200, 255, 0, 7, 255}
+		AppendedMergeBranchless(s, make([]uint8, 3))
+		if !stdsort.SliceIsSorted(s, func(i, j int) bool { return s[i] < s[j] }) {
+			t.Fatalf("not sorted: %v", s)
+		}
+	})
+
+	t.Run("int", func(t *testing.T) {
+		s := []int{-7, -5, -1, 0, 3, 8, 13, 21, 34, 55, 89, 144, 233, 377, 610, 987, -100, 50, 1000}
+		AppendedMergeBranchless(s, make([]int, 3))
+		if !stdsort.IntsAreSorted(s) {
+			t.Fatalf("not sorted: %v", s)
+		}
+	})
+
+	t.Run("uint", func(t *testing.T) {
+		s := []uint{1, 2, 3, 5, 8, 13, 21, 34, 55, 89, 144, 233, 377, 610, 987, 1597, math.MaxUint, 0, 100}
+		AppendedMergeBranchless(s, make([]uint, 3))
+		if !stdsort.SliceIsSorted(s, func(i, j int) bool { return s[i] < s[j] }) {
+			t.Fatalf("not sorted: %v", s)
+		}
+	})
+}
+
+func FuzzAppendedMergeBranchless(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedMergeBranchless(t, initial, tailLenght)
+	})
+}
+
+// mergeIntsBackward is the same as mergeIntsBackwardBranchless, but it
+// is a usual merge loop branching on the comparison (the baseline for
+// BenchmarkAppendedMergeBranchless).
+func mergeIntsBackward[E Integer](s []E, splitIdx int, buf []E) {
+	leftIdx, rightIdx := splitIdx-1, len(buf)-1
+	for outIdx := len(s) - 1; leftIdx >= 0 && rightIdx >= 0; outIdx-- {
+		if s[leftIdx] > buf[rightIdx] {
+			s[outIdx] = s[leftIdx]
+			leftIdx--
+		} else {
+			s[outIdx] = buf[rightIdx]
+			rightIdx--
+		}
+	}
+	copy(s, buf[:rightIdx+1])
+}
+
+func BenchmarkAppendedMergeBranchless(b *testing.B) {
+	const (
+		totalSize = 1400000
+		tailSize  = 400000
+		csCount   = 4
+	)
+	rng := rand.New(rand.NewSource(0))
+	in := make([][]int64, csCount)
+	for idx := range in {
+		in[idx] = make([]int64, totalSize)
+		s := in[idx]
+		for idx := range s {
+			s[idx] = rng.Int63()
+		}
+		stdsort.Slice(s[:totalSize-tailSize], func(i, j int) bool { return s[i] < s[j] })
+		stdsort.Slice(s[totalSize-tailSize:], func(i, j int) bool { return s[totalSize-tailSize+i] < s[totalSize-tailSize+j] })
+	}
+
+	cs := make([][]int64, csCount)
+	for idx := range cs {
+		cs[idx] = make([]int64, totalSize)
+	}
+	buf := make([]int64, tailSize)
+
+	// the tails are pre-sorted, to measure the merges only
+	for _, f := range []struct {
+		name string
+		fn   func(s []int64, splitIdx int, buf []int64)
+	}{
+		{name: "usual", fn: mergeIntsBackward[int64]},
+		{name: "branchless", fn: mergeIntsBackwardBranchless[int64]},
+	} {
+		b.Run(fmt.Sprintf("total-%d/tail-%d/merge-%s", totalSize, tailSize, f.name), func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				idx := i % csCount
+				if idx == 0 {
+					b.StopTimer()
+					for idx := range cs {
+						copy(cs[idx], in[idx])
+					}
+					b.StartTimer()
+				}
+				copy(buf, cs[idx][totalSize-tailSize:])
+				f.fn(cs[idx], totalSize-tailSize, buf)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"math/bits"
+)
+
+// AppendedMergeInsertion is the same as Appended, but it minimizes
+// the amount of calls of Less (at the cost of more bookkeeping and
+// moves), which is useful if Less is extremely expensive (for example
+// if it involves remote calls):
+//
+//   - The tail is sorted using the merge-insertion (Ford–Johnson)
+//     algorithm, which is close to the information-theoretic minimum
+//     of comparisons.
+//   - The sorted tail is merged into the prefix using the binary merge
+//     of Hwang and Lin, which requires about `k*log2(n/k) + k`
+//     comparisons instead of `k*log2(n)` of the straight binary
+//     insertions.
+//
+// The merge is stable with respect to the prefix: the elements of the tail
+// are placed after the equal elements of the prefix.
+//
+// T: O(k*ln(n) + n + k^2) [but only O(k*ln(n/k) + k*ln(k)) calls of Less]
+//
+// S: O(k) [if without `s`]
+func AppendedMergeInsertion[E any, S Interface[E]](s S, tailLength uint) {
+	appendedMergeInsertion(s, tailLength, s.Less)
+}
+
+// AppendedMergeInsertionWithStats is the same as AppendedMergeInsertion,
+// but it also returns the statistics (see AppendedWithStats): Branches
+// is always empty and Comparisons is the amount of calls of Less.
+//
+// It is useful to compare the amount of comparisons with AppendedWithStats.
+func AppendedMergeInsertionWithStats[E any, S Interface[E]](s S, tailLength uint) AppendedStats {
+	stats := AppendedStats{
+		Branches: map[string]int{},
+	}
+	stats.Strategy = appendedMergeInsertion(s, tailLength, func(i, j int) bool {
+		stats.Comparisons++
+		return s.Less(i, j)
+	})
+	return stats
+}
+
+// appendedMergeInsertion is the implementation of AppendedMergeInsertion,
+// which compares the elements of s using less. It returns the label of
+// the chosen strategy.
+//
+// The strategy is chosen (and reported to OnStrategy) the same way as
+// in the other variants merging a tail of any length, except there is
+// no separate check if the tail is after the prefix (the merge finds it
+// out in O(k) comparisons anyway). But the tail is merged even if
+// StrategyFallbackSort is chosen (for a slice shorter than
+// MinAppendedSize), since a full sort would take more comparisons
+// and would not be stable.
+func appendedMergeInsertion[E any, S Interface[E]](s S, tailLength uint, less func(i, j int) bool) string {
+	strategy := startAppended(s, tailLength, appendedPolicy{shouldUse: alwaysUseAppended, noSortTail: true})
+	if strategy == StrategyAlreadySorted {
+		return strategy
+	}
+	splitIdx := len(s) - int(tailLength)
+
+	items := make([]int, tailLength)
+	for idx := range items {
+		items[idx] = splitIdx + idx
+	}
+	order := mergeInsertionSort(items, less)
+
+	buf := make([]E, tailLength)
+	for idx, pos := range order {
+		buf[idx] = s[items[pos]]
+	}
+	mergeHwangLin(s, splitIdx, buf, less)
+	return strategy
+}
+
+// mergeInsertionSort sorts the items (indexes) using the merge-insertion
+// (Ford–Johnson) algorithm and returns the order of the items:
+// the positions (in items) of the least item, of the next one and so on.
+func mergeInsertionSort(items []int, less func(i, j int) bool) []int {
+	if len(items) < 2 {
+		return make([]int, len(items))
+	}
+
+	// Split the items into pairs and sort the greater items of the pairs
+	// recursively. The recursion returns the order of the pairs, so
+	// the partners are found by the positions of the pairs.
+	pairsCount := len(items) / 2
+	greater := make([]int, pairsCount)
+	greaterPos := make([]int, pairsCount)
+	lesserPos := make([]int, pairsCount)
+	for idx := range greater {
+		a, b := 2*idx, 2*idx+1
+		if less(items[b], items[a]) {
+			a, b = b, a
+		}
+		greater[idx] = items[b]
+		greaterPos[idx], lesserPos[idx] = b, a
+	}
+	pairsOrder := mergeInsertionSort(greater, less)
+
+	// The main chain: the partner of the least greater item, and
+	// the greater items. The rest of the partners (and the odd item)
+	// are inserted into the chain.
+	chain := make([]int, 0, len(items))
+	chain = append(chain, lesserPos[pairsOrder[0]])
+	for _, pair := range pairsOrder {
+		chain = append(chain, greaterPos[pair])
+	}
+	pending := make([]int, 0, pairsCount)
+	for _, pair := range pairsOrder[1:] {
+		pending = append(pending, lesserPos[pair])
+	}
+	if len(items)%2 == 1 {
+		pending = append(pending, len(items)-1)
+	}
+
+	// The pending items are inserted in groups, which sizes are chosen so
+	// that each binary search is over a range of length `2^t-1`:
+	// the partner of an item bounds the search range from the right,
+	// and the items are inserted from the last one of the group.
+	//
+	// bounds are the current indexes (in chain) of the partners of
+	// the items of the group: the partner of pending[idx] is the greater
+	// item at index idx+2 of the initial chain, and all the items of
+	// the previous groups were inserted before it (before their own
+	// partners). An inserted item shifts the partners after it.
+	bounds := make([]int, 0, len(pending))
+	groupStart, prevGroupSize := 0, 0
+	for power := 2; groupStart < len(pending); power *= 2 {
+		groupSize := power - prevGroupSize
+		prevGroupSize = groupSize
+		groupEnd := groupStart + groupSize
+		if groupEnd > len(pending) {
+			groupEnd = len(pending)
+		}
+		bounds = bounds[:0]
+		for idx := groupStart; idx < groupEnd; idx++ {
+			bounds = append(bounds, idx+2+groupStart)
+		}
+		for idx := groupEnd - 1; idx >= groupStart; idx-- {
+			bound := bounds[idx-groupStart]
+			if idx >= pairsCount-1 {
+				// the odd item has no partner
+				bound = len(chain)
+			}
+			insertIdx := searchUpperBound(chain[:bound], items, pending[idx], less)
+			chain = append(chain, 0)
+			copy(chain[insertIdx+1:], chain[insertIdx:])
+			chain[insertIdx] = pending[idx]
+			for prevIdx := range bounds[:idx-groupStart] {
+				if insertIdx <= bounds[prevIdx] {
+					bounds[prevIdx]++
+				}
+			}
+		}
+		groupStart = groupEnd
+	}
+	return chain
+}
+
+// searchUpperBound returns the index of the first position of the sorted
+// chain (of positions in items) which item is greater than items[pos].
+func searchUpperBound(chain, items []int, pos int, less func(i, j int) bool) int {
+	lo, hi := 0, len(chain)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if less(items[pos], items[chain[mid]]) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+// mergeHwangLin merges the sorted buf into the sorted s[:splitIdx]
+// (where s[splitIdx:] is a free space of the length of buf) using
+// the binary merge algorithm of Hwang and Lin. The elements of buf are
+// placed after the equal elements of the prefix.
+func mergeHwangLin[E any, S Interface[E]](s S, splitIdx int, buf []E, less func(i, j int) bool) {
+	aCount, bCount := splitIdx, len(buf)
+	outIdx := len(s) - 1
+	// The comparisons are done through less (of s), so an element of buf
+	// is placed into the free slot s[outIdx] before being compared
+	// (there is always at least one free slot while buf is not empty).
+	bLessA := func(bIdx, aIdx int) bool {
+		s[outIdx] = buf[bIdx]
+		return less(outIdx, aIdx)
+	}
+	for aCount > 0 && bCount > 0 {
+		if aCount >= bCount {
+			// compare the last b with a, which has 2^t-1 elements after it
+			step := 1 << (bits.Len(uint(aCount/bCount)) - 1)
+			if bLessA(bCount-1, aCount-step) {
+				// all the 2^t last a-s are greater than the last b
+				copy(s[outIdx-step+1:outIdx+1], s[aCount-step:aCount])
+				outIdx -= step
+				aCount -= step
+				continue
+			}
+			// the last b is between the last 2^t-1 a-s
+			lo, hi := aCount-step+1, aCount
+			for lo < hi {
+				mid := int(uint(lo+hi) >> 1)
+				if bLessA(bCount-1, mid) {
+					hi = mid
+				} else {
+					lo = mid + 1
+				}
+			}
+			greaterCount := aCount - lo
+			copy(s[outIdx-greaterCount+1:outIdx+1], s[lo:aCount])
+			outIdx -= greaterCount
+			aCount = lo
+			s[outIdx] = buf[bCount-1]
+			outIdx--
+			bCount--
+			continue
+		}
+
+		// the same, but the roles of a and b are swapped
+		step := 1 << (bits.Len(uint(bCount/aCount)) - 1)
+		if !bLessA(bCount-step, aCount-1) {
+			// all the 2^t last b-s are not less than the last a
+			copy(s[outIdx-step+1:outIdx+1], buf[bCount-step:bCount])
+			outIdx -= step
+			bCount -= step
+			continue
+		}
+		lo, hi := bCount-step+1, bCount
+		for lo < hi {
+			mid := int(uint(lo+hi) >> 1)
+			if !bLessA(mid, aCount-1) {
+				hi = mid
+			} else {
+				lo = mid + 1
+			}
+		}
+		notLessCount := bCount - lo
+		copy(s[outIdx-notLessCount+1:outIdx+1], buf[lo:bCount])
+		outIdx -= notLessCount
+		bCount = lo
+		s[outIdx] = s[aCount-1]
+		outIdx--
+		aCount--
+	}
+	copy(s[:bCount], buf[:bCount])
+}
@@ -0,0 +1,172 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+func testAppendedMergeInsertion(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		AppendedMergeInsertion(intSlice(s), tailLenght)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedMergeInsertion(t *testing.T) {
+	testAppendedMergeInsertion(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedMergeInsertion(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedMergeInsertion(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 16, 18, 20, 21}, 4)
+	testAppendedMergeInsertion(t, []byte{5, 4, 3, 2, 1}, 5)
+	testAppendedMergeInsertion(t, []byte{1, 2, 3, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0}, 10)
+
+	t.Run("stable", func(t *testing.T) {
+		s := keySeqs{{1, 0}, {2, 1}, {2, 2}, {3, 3}, {2, 4}, {1, 5}, {3, 6}}
+		AppendedMergeInsertion(s, 3)
+		expected := []int{0, 5, 1, 2, 4, 3, 6}
+		for idx, v := range s {
+			if v.Seq != expected[idx] {
+				t.Fatalf("%v; expected order: %v", s, expected)
+			}
+		}
+	})
+}
+
+func TestMergeInsertionSortComparisons(t *testing.T) {
+	// the worst case amount of comparisons of the merge-insertion:
+	// sum of ceil(log2(3k/4)) for k in [1, n]
+	maxComparisons := func(n int) int {
+		var sum int
+		for k := 1; k <= n; k++ {
+			for c := 0; ; c++ {
+				if 1<<c*4 >= 3*k {
+					sum += c
+					break
+				}
+			}
+		}
+		return sum
+	}
+
+	rng := rand.New(rand.NewSource(0))
+	for n := 0; n <= 40; n++ {
+		for run := 0; run < 50; run++ {
+			values := rng.Perm(n)
+			var comparisons int
+			items := make([]int, n)
+			for idx := range items {
+				items[idx] = idx
+			}
+			items = mergeInsertionSort(items, func(i, j int) bool {
+				comparisons++
+				return values[i] < values[j]
+			})
+			for idx := 1; idx < len(items); idx++ {
+				if values[items[idx]] < values[items[idx-1]] {
+					t.Fatalf("not sorted: %v", items)
+				}
+			}
+			if comparisons > maxComparisons(n) {
+				t.Fatalf("too many comparisons for %d items: %d > %d", n, comparisons, maxComparisons(n))
+			}
+		}
+	}
+}
+
+func TestAppendedMergeInsertionWithStats(t *testing.T) {
+	const totalSize = 65536
+	rng := rand.New(rand.NewSource(0))
+	for _, tailSize := range []int{0, 1, 4, 16, 256} {
+		s := make(intSlice, totalSize)
+		for idx := range s {
+			s[idx] = rng.Intn(totalSize)
+		}
+		stdsort.Ints(s[:totalSize-tailSize])
+		c := make(intSlice, totalSize)
+		copy(c, s)
+
+		stats := AppendedMergeInsertionWithStats(s, uint(tailSize))
+		appendedStats := AppendedWithStats(c, uint(tailSize))
+		if !intsEqual(c, s) {
+			t.Fatalf("tail %d: %v != %v", tailSize, s, c)
+		}
+		expectedStrategy := StrategyGroupInsert
+		if tailSize == 0 {
+			expectedStrategy = StrategyAlreadySorted
+		}
+		if stats.Strategy != expectedStrategy || len(stats.Branches) != 0 {
+			t.Fatalf("tail %d: unexpected stats: %v", tailSize, stats)
+		}
+		if stats.Comparisons > appendedStats.Comparisons {
+			t.Fatalf("tail %d: more comparisons than Appended: %d > %d", tailSize, stats.Comparisons, appendedStats.Comparisons)
+		}
+	}
+}
+
+func FuzzAppendedMergeInsertion(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedMergeInsertion(t, initial, tailLenght)
+	})
+}
+
+func BenchmarkAppendedMergeInsertion(b *testing.B) {
+	const (
+		totalSize = 65536
+		csCount   = 20
+	)
+	for _, tailSize := range []int{4, 16, 256} {
+		rng := rand.New(rand.NewSource(0))
+		in := make([][]int, csCount)
+		for idx := range in {
+			in[idx] = make([]int, totalSize)
+			s := in[idx]
+			for idx := range s {
+				s[idx] = rng.Intn(totalSize)
+			}
+			stdsort.Ints(s[:totalSize-tailSize])
+		}
+
+		for _, f := range []struct {
+			name string
+			fn   func(intSlice, uint) AppendedStats
+		}{
+			{name: "Appended", fn: AppendedWithStats[int, intSlice]},
+			{name: "AppendedMergeInsertion", fn: AppendedMergeInsertionWithStats[int, intSlice]},
+		} {
+			b.Run(fmt.Sprintf("total-%d/tail-%d/%s", totalSize, tailSize, f.name), func(b *testing.B) {
+				s := make([]intSlice, csCount)
+				for idx := range s {
+					s[idx] = make(intSlice, totalSize)
+				}
+				var comparisons int
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range s {
+							copy(s[idx], in[idx])
+						}
+						b.StartTimer()
+					}
+					comparisons += f.fn(s[idx], uint(tailSize)).Comparisons
+				}
+				b.ReportMetric(float64(comparisons)/float64(b.N), "cmps/op")
+			})
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"sync"
+
+	"github.com/go-ng/sort"
+)
+
+// AppendedMergeParallel is an alternative to Appended for the case where
+// the unsorted tail is big (where Appended fallbacks to a full sort).
+//
+// It sorts the tail and then merges it with the sorted prefix into
+// a temporary buffer using up to `workers` goroutines. The work is split
+// between goroutines using the merge-path partitioning: the output is cut
+// into equal segments and the boundaries of the corresponding input
+// ranges are found through a binary search.
+//
+// The merge is stable: if elements of the prefix and the tail are equal,
+// then the prefix ones go first.
+//
+// T: O(k*ln(k) + n/w + w*ln(n))
+//
+// S: O(n)
+func AppendedMergeParallel[E any, S Interface[E]](s S, tailLength uint, workers int) {
+	strategy := startAppended(s, tailLength, appendedPolicy{shouldUse: alwaysUseAppended})
+	if strategy != StrategyGroupInsert {
+		finishAppended(s, tailLength, strategy)
+		return
+	}
+
+	length := len(s)
+	splitIdx := length - int(tailLength)
+	sort.Sort(s[splitIdx:])
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > length {
+		workers = length
+	}
+
+	buf := make([]E, length)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		outStart, outEnd := length*w/workers, length*(w+1)/workers
+		go func() {
+			defer wg.Done()
+			leftStart := mergePathSplit(s, splitIdx, outStart)
+			leftEnd := mergePathSplit(s, splitIdx, outEnd)
+			mergeRanges(
+				buf[outStart:outEnd], s,
+				leftStart, leftEnd,
+				splitIdx+outStart-leftStart, splitIdx+outEnd-leftEnd,
+			)
+		}()
+	}
+	wg.Wait()
+
+	copy(s, buf)
+}
+
+// mergePathSplit returns how many elements of the left part (s[:splitIdx])
+// are among the first `diagonal` elements of the stable merge of the left
+// and the right (s[splitIdx:]) parts.
+func mergePathSplit[E any, S Interface[E]](s S, splitIdx, diagonal int) int {
+	rightLength := len(s) - splitIdx
+	lo := diagonal - rightLength
+	if lo < 0 {
+		lo = 0
+	}
+	hi := diagonal
+	if hi > splitIdx {
+		hi = splitIdx
+	}
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		// is right[diagonal-mid-1] strictly less than left[mid]?
+		if s.Less(splitIdx+diagonal-mid-1, mid) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+// mergeRanges stably merges sorted ranges s[leftIdx:leftEnd] and
+// s[rightIdx:rightEnd] into dst.
+func mergeRanges[E any, S Interface[E]](dst []E, s S, leftIdx, leftEnd, rightIdx, rightEnd int) {
+	outIdx := 0
+	for leftIdx < leftEnd && rightIdx < rightEnd {
+		if s.Less(rightIdx, leftIdx) {
+			dst[outIdx] = s[rightIdx]
+			rightIdx++
+		} else {
+			dst[outIdx] = s[leftIdx]
+			leftIdx++
+		}
+		outIdx++
+	}
+	outIdx += copy(dst[outIdx:], s[leftIdx:leftEnd])
+	copy(dst[outIdx:], s[rightIdx:rightEnd])
+}
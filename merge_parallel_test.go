@@ -0,0 +1,111 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+
+	"github.com/go-ng/sort"
+)
+
+func testAppendedMergeParallel(t *testing.T, initial []byte, tailLenght uint, workers int) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(fmt.Sprintf("%s (workers: %d)", testName, workers), func(t *testing.T) {
+		AppendedMergeParallel(intSlice(s), tailLenght, workers)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedMergeParallel(t *testing.T) {
+	for _, workers := range []int{0, 1, 3, 100} {
+		testAppendedMergeParallel(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4, workers)
+		testAppendedMergeParallel(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4, workers)
+		testAppendedMergeParallel(t, []byte{49, 255, 127}, 2, workers)
+		testAppendedMergeParallel(t, []byte{65, 76, 173, 37, 67, 145}, 5, workers)
+	}
+}
+
+func FuzzAppendedMergeParallel(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedMergeParallel(t, initial, tailLenght, rand.Intn(8))
+	})
+}
+
+func TestAppendedMergeParallelStable(t *testing.T) {
+	s := randomKeySeqs()
+	stdsort.Stable(stdInterface[keySeq, keySeqs](s[:600]))
+	AppendedMergeParallel(s, 400, 4)
+	for idx := 1; idx < len(s); idx++ {
+		prev, cur := s[idx-1], s[idx]
+		if prev.Key > cur.Key {
+			t.Fatalf("not sorted at %d: %v", idx, s)
+		}
+		if prev.Key == cur.Key && prev.Seq >= 600 && cur.Seq < 600 {
+			t.Fatalf("a tail element is before a prefix element at %d: %v", idx, s)
+		}
+	}
+}
+
+func BenchmarkAppendedMergeParallel(b *testing.B) {
+	const (
+		totalSize = 1024 * 1024
+		tailSize  = 400 * 1024
+		csCount   = 4
+	)
+
+	rng := rand.New(rand.NewSource(0))
+	in := make([][]int, csCount)
+	for idx := range in {
+		in[idx] = make([]int, totalSize)
+		s := in[idx]
+		for idx := range s {
+			s[idx] = rng.Intn(totalSize)
+		}
+		stdsort.Ints(s[:totalSize-tailSize])
+	}
+
+	cs := make([]intSlice, csCount)
+	for idx := range cs {
+		cs[idx] = make([]int, totalSize)
+	}
+
+	buf := make([]int, tailSize)
+	for _, bc := range []struct {
+		name string
+		fn   func(s intSlice)
+	}{
+		{"Sort", func(s intSlice) { sort.Sort(s) }},
+		{"AppendedWithBuf", func(s intSlice) { AppendedWithBuf(s, buf) }},
+		{"AppendedMergeParallel-1", func(s intSlice) { AppendedMergeParallel(s, tailSize, 1) }},
+		{"AppendedMergeParallel-4", func(s intSlice) { AppendedMergeParallel(s, tailSize, 4) }},
+		{"AppendedMergeParallel-16", func(s intSlice) { AppendedMergeParallel(s, tailSize, 16) }},
+	} {
+		b.Run(fmt.Sprintf("total-%d/tail-%d/%s", totalSize, tailSize, bc.name), func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				idx := i % csCount
+				if idx == 0 {
+					b.StopTimer()
+					for idx := range cs {
+						copy(cs[idx], in[idx])
+					}
+					b.StartTimer()
+				}
+				bc.fn(cs[idx])
+			}
+		})
+	}
+}
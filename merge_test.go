@@ -0,0 +1,270 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"testing"
+)
+
+func testMerge(t *testing.T, left, right []keySeq) {
+	t.Run(fmt.Sprintf("%v+%v", left, right), func(t *testing.T) {
+		dst := make(keySeqs, len(left)+len(right))
+		Merge(dst, left, right)
+
+		expected := append(append(keySeqs{}, left...), right...)
+		stdsort.SliceStable(expected, func(i, j int) bool {
+			return expected[i].Key < expected[j].Key
+		})
+		for idx := range expected {
+			if dst[idx] != expected[idx] {
+				t.Fatalf("%v != %v", dst, expected)
+			}
+		}
+	})
+}
+
+func TestMerge(t *testing.T) {
+	keySeqsOf := func(seqBase int, keys ...int) []keySeq {
+		s := make([]keySeq, len(keys))
+		for idx, key := range keys {
+			s[idx] = keySeq{Key: key, Seq: seqBase + idx}
+		}
+		return s
+	}
+
+	testMerge(t, nil, nil)
+	testMerge(t, keySeqsOf(0, 1, 2, 3), nil)
+	testMerge(t, nil, keySeqsOf(0, 1, 2, 3))
+	testMerge(t, keySeqsOf(0, 1, 3, 5, 7), keySeqsOf(100, 2, 4, 6, 8))
+	testMerge(t, keySeqsOf(0, 1, 2, 2, 5, 7), keySeqsOf(100, 2, 2, 5, 6))
+	testMerge(t, keySeqsOf(0, 5, 6), keySeqsOf(100, 1, 2, 3))
+	testMerge(t, keySeqsOf(0, 1, 1, 1), keySeqsOf(100, 1, 1))
+
+	t.Run("wrong_dst_length", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		Merge(make(intSlice, 3), []int{1}, []int{2, 3, 4})
+	})
+}
+
+func testMergeSortedNew(t *testing.T, a, b []keySeq) {
+	t.Run(fmt.Sprintf("%d+%d", len(a), len(b)), func(t *testing.T) {
+		origA := append([]keySeq{}, a...)
+		origB := append([]keySeq{}, b...)
+		result := MergeSortedNew(a, b, func(a, b keySeq) bool {
+			return a.Key < b.Key
+		})
+
+		if len(result) != len(a)+len(b) {
+			t.Fatalf("unexpected length: %d", len(result))
+		}
+		if len(result) > 0 && len(a) > 0 && &result[0] == &a[0] {
+			t.Fatal("the result shares the memory with a")
+		}
+		for idx := range a {
+			if a[idx] != origA[idx] {
+				t.Fatal("a is modified")
+			}
+		}
+		for idx := range b {
+			if b[idx] != origB[idx] {
+				t.Fatal("b is modified")
+			}
+		}
+
+		expected := append(append([]keySeq{}, a...), b...)
+		stdsort.SliceStable(expected, func(i, j int) bool {
+			return expected[i].Key < expected[j].Key
+		})
+		for idx := range expected {
+			// the order of equal elements of b is not guaranteed, but
+			// they go after the equal elements of a
+			if result[idx].Key != expected[idx].Key || (expected[idx].Seq < len(a)) != (result[idx].Seq < len(a)) {
+				t.Fatalf("%v != %v", result, expected)
+			}
+			if expected[idx].Seq < len(a) && result[idx] != expected[idx] {
+				t.Fatalf("%v != %v", result, expected)
+			}
+		}
+	})
+}
+
+func TestMergeSortedNew(t *testing.T) {
+	sortedKeySeqs := func(seqBase, length, maxKey int) []keySeq {
+		s := make([]keySeq, length)
+		for idx := range s {
+			s[idx].Key = rand.Intn(maxKey)
+		}
+		stdsort.Slice(s, func(i, j int) bool {
+			return s[i].Key < s[j].Key
+		})
+		for idx := range s {
+			s[idx].Seq = seqBase + idx
+		}
+		return s
+	}
+
+	testMergeSortedNew(t, nil, nil)
+	testMergeSortedNew(t, sortedKeySeqs(0, 10, 5), nil)
+	testMergeSortedNew(t, nil, sortedKeySeqs(0, 10, 5))
+	for _, lengths := range [][2]int{{10, 10}, {1000, 3}, {1000, 100}, {3, 1000}, {1000, 1000}} {
+		// overlapping ranges with many equal keys
+		testMergeSortedNew(t, sortedKeySeqs(0, lengths[0], 50), sortedKeySeqs(lengths[0], lengths[1], 50))
+	}
+}
+
+func FuzzMergeSortedNew(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a, b []byte) {
+		toKeySeqs := func(seqBase int, in []byte) []keySeq {
+			stdsort.Slice(in, func(i, j int) bool {
+				return in[i] < in[j]
+			})
+			s := make([]keySeq, len(in))
+			for idx, v := range in {
+				s[idx] = keySeq{Key: int(v % 16), Seq: seqBase + idx}
+			}
+			stdsort.SliceStable(s, func(i, j int) bool {
+				return s[i].Key < s[j].Key
+			})
+			for idx := range s {
+				s[idx].Seq = seqBase + idx
+			}
+			return s
+		}
+		testMergeSortedNew(t, toKeySeqs(0, a), toKeySeqs(len(a), b))
+	})
+}
+
+// sliceStream returns a function yielding the elements of s.
+func sliceStream[E any](s []E) func() (E, bool) {
+	return func() (E, bool) {
+		var zero E
+		if len(s) == 0 {
+			return zero, false
+		}
+		v := s[0]
+		s = s[1:]
+		return v, true
+	}
+}
+
+func testMergeStream(t *testing.T, dst, stream []keySeq) {
+	t.Run(fmt.Sprintf("%d+%d", len(dst), len(stream)), func(t *testing.T) {
+		expected := append(append([]keySeq{}, dst...), stream...)
+		stdsort.SliceStable(expected, func(i, j int) bool {
+			return expected[i].Key < expected[j].Key
+		})
+
+		result := MergeStream(dst, sliceStream(stream), func(a, b keySeq) bool {
+			return a.Key < b.Key
+		})
+		if len(result) != len(expected) {
+			t.Fatalf("unexpected length: %d", len(result))
+		}
+		for idx := range expected {
+			// the order of equal streamed elements is not guaranteed, but
+			// they go after the equal elements of dst
+			if result[idx].Key != expected[idx].Key || (expected[idx].Seq < len(dst)) != (result[idx].Seq < len(dst)) {
+				t.Fatalf("%v != %v", result, expected)
+			}
+			if expected[idx].Seq < len(dst) && result[idx] != expected[idx] {
+				t.Fatalf("%v != %v", result, expected)
+			}
+		}
+	})
+}
+
+func TestMergeStream(t *testing.T) {
+	sortedKeySeqs := func(seqBase, length, maxKey int) []keySeq {
+		s := make([]keySeq, length)
+		for idx := range s {
+			s[idx].Key = rand.Intn(maxKey)
+		}
+		stdsort.Slice(s, func(i, j int) bool {
+			return s[i].Key < s[j].Key
+		})
+		for idx := range s {
+			s[idx].Seq = seqBase + idx
+		}
+		return s
+	}
+
+	testMergeStream(t, nil, nil)
+	// an exhausted stream
+	testMergeStream(t, sortedKeySeqs(0, 10, 5), nil)
+	// an empty dst
+	testMergeStream(t, nil, sortedKeySeqs(0, 10, 5))
+	for _, lengths := range [][2]int{{10, 10}, {1000, 3}, {1000, 100}, {3, 1000}, {1000, 1000}} {
+		testMergeStream(t, sortedKeySeqs(0, lengths[0], 50), sortedKeySeqs(lengths[0], lengths[1], 50))
+	}
+
+	t.Run("grows", func(t *testing.T) {
+		dst := make([]int, 3, 3)
+		dst[0], dst[1], dst[2] = 1, 3, 5
+		dst = MergeStream(dst, sliceStream([]int{0, 2, 4, 6}), func(a, b int) bool {
+			return a < b
+		})
+		if !intsEqual(dst, []int{0, 1, 2, 3, 4, 5, 6}) {
+			t.Fatalf("unexpected result: %v", dst)
+		}
+	})
+}
+
+func FuzzMergeStream(f *testing.F) {
+	f.Fuzz(func(t *testing.T, a, b []byte) {
+		toKeySeqs := func(seqBase int, in []byte) []keySeq {
+			stdsort.Slice(in, func(i, j int) bool {
+				return in[i] < in[j]
+			})
+			s := make([]keySeq, len(in))
+			for idx, v := range in {
+				s[idx] = keySeq{Key: int(v % 16), Seq: seqBase + idx}
+			}
+			stdsort.SliceStable(s, func(i, j int) bool {
+				return s[i].Key < s[j].Key
+			})
+			for idx := range s {
+				s[idx].Seq = seqBase + idx
+			}
+			return s
+		}
+		testMergeStream(t, toKeySeqs(0, a), toKeySeqs(len(a), b))
+	})
+}
+
+func BenchmarkMergeStream(b *testing.B) {
+	less := func(a, b int) bool {
+		return a < b
+	}
+	for _, streamLength := range []int{10, 30, 100, 300, 1000, 100000} {
+		b.Run(fmt.Sprintf("stream%d", streamLength), func(b *testing.B) {
+			const dstLength = 100000
+			stream := make([]int, streamLength)
+			for idx := range stream {
+				stream[idx] = rand.Intn(dstLength)
+			}
+			stdsort.Ints(stream)
+			dst := make([]int, dstLength, dstLength+streamLength)
+			for idx := range dst {
+				dst[idx] = idx
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				for idx := range dst {
+					dst[idx] = idx
+				}
+				b.StartTimer()
+				MergeStream(dst, sliceStream(stream), less)
+			}
+		})
+	}
+}
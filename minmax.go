@@ -0,0 +1,100 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+// Min returns the index of the least element of the slice. If there
+// are multiple least elements, then the index of the first one is returned.
+//
+// If the slice is empty, then -1 is returned.
+//
+// T: O(n)
+//
+// S: O(1)
+func Min[E any, S Interface[E]](s S) int {
+	if len(s) == 0 {
+		return -1
+	}
+	minIdx := 0
+	for idx := 1; idx < len(s); idx++ {
+		if s.Less(idx, minIdx) {
+			minIdx = idx
+		}
+	}
+	return minIdx
+}
+
+// Max returns the index of the greatest element of the slice. If there
+// are multiple greatest elements, then the index of the first one is returned.
+//
+// If the slice is empty, then -1 is returned.
+//
+// T: O(n)
+//
+// S: O(1)
+func Max[E any, S Interface[E]](s S) int {
+	if len(s) == 0 {
+		return -1
+	}
+	maxIdx := 0
+	for idx := 1; idx < len(s); idx++ {
+		if s.Less(maxIdx, idx) {
+			maxIdx = idx
+		}
+	}
+	return maxIdx
+}
+
+// MinMax returns the indexes of the least and of the greatest elements of
+// the slice in a single pass. It is equivalent to calling Min and Max, but
+// requires only ~3n/2 calls of Less instead of 2n.
+//
+// If the slice is empty, then -1 is returned for both indexes.
+//
+// T: O(n)
+//
+// S: O(1)
+func MinMax[E any, S Interface[E]](s S) (minIdx, maxIdx int) {
+	if len(s) == 0 {
+		return -1, -1
+	}
+
+	// Strategy:
+	//
+	// Elements are compared in pairs: the lesser one of a pair is compared
+	// only with the current minimum and the greater one only with the
+	// current maximum.
+	//
+	// To return the first occurrence of an extremum, the minimum is updated
+	// only on strict "less" and the maximum only on strict "greater". Within
+	// a pair of equal elements the left one is the "lesser" one, so when
+	// such a pair becomes the new maximum we additionally check for the tie
+	// (which is rare, since the maximum is rarely updated).
+	minIdx, maxIdx = 0, 0
+	idx := 1
+	for ; idx+1 < len(s); idx += 2 {
+		lo, hi := idx, idx+1
+		if s.Less(hi, lo) {
+			lo, hi = hi, lo
+		}
+		if s.Less(lo, minIdx) {
+			minIdx = lo
+		}
+		if s.Less(maxIdx, hi) {
+			if hi > lo && !s.Less(lo, hi) {
+				hi = lo
+			}
+			maxIdx = hi
+		}
+	}
+	if idx < len(s) {
+		if s.Less(idx, minIdx) {
+			minIdx = idx
+		}
+		if s.Less(maxIdx, idx) {
+			maxIdx = idx
+		}
+	}
+	return
+}
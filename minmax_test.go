@@ -0,0 +1,53 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"testing"
+)
+
+func testMinMax(t *testing.T, initial []byte) {
+	s := make(intSlice, len(initial))
+	for idx, v := range initial {
+		s[idx] = int(v)
+	}
+
+	expectedMin, expectedMax := -1, -1
+	for idx, v := range s {
+		if expectedMin == -1 || v < s[expectedMin] {
+			expectedMin = idx
+		}
+		if expectedMax == -1 || v > s[expectedMax] {
+			expectedMax = idx
+		}
+	}
+
+	if minIdx := Min(s); minIdx != expectedMin {
+		t.Fatalf("Min: %d != %d; slice: %v", minIdx, expectedMin, s)
+	}
+	if maxIdx := Max(s); maxIdx != expectedMax {
+		t.Fatalf("Max: %d != %d; slice: %v", maxIdx, expectedMax, s)
+	}
+	minIdx, maxIdx := MinMax(s)
+	if minIdx != expectedMin || maxIdx != expectedMax {
+		t.Fatalf("MinMax: (%d, %d) != (%d, %d); slice: %v", minIdx, maxIdx, expectedMin, expectedMax, s)
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	testMinMax(t, nil)
+	testMinMax(t, []byte{5})
+	testMinMax(t, []byte{2, 1})
+	testMinMax(t, []byte{1, 3, 3, 0, 0, 2})
+	testMinMax(t, []byte{7, 7, 7, 7})
+	testMinMax(t, []byte{1, 9, 9, 0})
+	testMinMax(t, []byte{4, 1, 9, 0, 9, 0, 3})
+}
+
+func FuzzMinMax(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial []byte) {
+		testMinMax(t, initial)
+	})
+}
@@ -0,0 +1,46 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"math/bits"
+
+	"github.com/go-ng/slices"
+	"github.com/go-ng/sort"
+)
+
+// NearlySorted sorts a slice in assumption that it is already almost
+// sorted, but (unlike `Appended`) the unsorted elements may be scattered
+// all over the slice.
+//
+// It is an adaptive binary insertion sort: it is O(n) on an already sorted
+// slice and each displaced element costs a binary search and a shift. If
+// the shifts turn out to be too expensive (the slice is not that "nearly"
+// sorted), then the rest of the slice is handled by `Appended` (which
+// fallbacks to a full sort if required).
+//
+// T: O(n + d*ln(n) + d*m) -- where `d` is the amount of displaced elements
+// and `m` is the mean displacement distance; bounded by O(n*ln(n)).
+//
+// S: O(1)
+func NearlySorted[E any, S Interface[E]](s S) {
+	length := len(s)
+	movesLeft := length * bits.Len(uint(length))
+	for idx := 1; idx < length; idx++ {
+		if !s.Less(idx, idx-1) {
+			continue
+		}
+
+		insertIdx := sort.Search(idx-1, func(i int) bool {
+			return s.Less(idx, i)
+		})
+		movesLeft -= idx - insertIdx
+		if movesLeft < 0 {
+			Appended(s, uint(length-idx))
+			return
+		}
+		slices.Rotate(s[insertIdx:idx+1], 1)
+	}
+}
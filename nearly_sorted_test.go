@@ -0,0 +1,111 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"testing"
+
+	"github.com/go-ng/sort"
+)
+
+func testNearlySorted(t *testing.T, initial []byte) {
+	s := make(intSlice, len(initial))
+	for idx, v := range initial {
+		s[idx] = int(v)
+	}
+	expected := make([]int, len(s))
+	copy(expected, s)
+	stdsort.Ints(expected)
+
+	t.Run(fmt.Sprintf("%v", s), func(t *testing.T) {
+		NearlySorted(s)
+		if !intsEqual(expected, s) {
+			t.Fatalf("%v != %v", expected, s)
+		}
+	})
+}
+
+func TestNearlySorted(t *testing.T) {
+	testNearlySorted(t, nil)
+	testNearlySorted(t, []byte{1})
+	testNearlySorted(t, []byte{1, 2, 3, 4, 5})
+	testNearlySorted(t, []byte{1, 2, 7, 4, 5, 6, 3, 8})
+	testNearlySorted(t, []byte{9, 8, 7, 6, 5, 4, 3, 2, 1, 0})
+}
+
+func FuzzNearlySorted(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial []byte) {
+		testNearlySorted(t, initial)
+	})
+}
+
+func BenchmarkNearlySorted(b *testing.B) {
+	const (
+		totalSize       = 65536
+		maxDisplacement = 16
+	)
+	for _, displacedPercent := range []int{1, 5, 20} {
+		b.Run(fmt.Sprintf("total-%d/displaced-%d", totalSize, displacedPercent), func(b *testing.B) {
+			csCount := 20
+
+			rng := rand.New(rand.NewSource(0))
+			in := make([][]int, csCount)
+			for idx := range in {
+				s := make([]int, totalSize)
+				for idx := range s {
+					s[idx] = idx
+				}
+				for i := 0; i < totalSize*displacedPercent/100; i++ {
+					from := rng.Intn(totalSize)
+					to := from + rng.Intn(2*maxDisplacement+1) - maxDisplacement
+					if to < 0 || to >= totalSize {
+						continue
+					}
+					s[from], s[to] = s[to], s[from]
+				}
+				in[idx] = s
+			}
+
+			cs := make([]intSlice, csCount)
+			for idx := range cs {
+				cs[idx] = make([]int, totalSize)
+			}
+
+			b.Run("Sort", func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range cs {
+							copy(cs[idx], in[idx])
+						}
+						b.StartTimer()
+					}
+					sort.Sort(cs[idx])
+				}
+			})
+			b.Run("NearlySorted", func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range cs {
+							copy(cs[idx], in[idx])
+						}
+						b.StartTimer()
+					}
+					NearlySorted(cs[idx])
+				}
+			})
+		})
+	}
+}
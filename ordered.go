@@ -0,0 +1,42 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"cmp"
+)
+
+// OrderedAsc implements Interface for a slice of an ordered type
+// in ascending order.
+//
+// NaN values are ordered before any other values (like `cmp.Less`).
+type OrderedAsc[E cmp.Ordered] []E
+
+// Less implements Interface.
+func (s OrderedAsc[E]) Less(i, j int) bool {
+	return cmp.Less(s[i], s[j])
+}
+
+// Desc returns the same slice (sharing the backing array) as OrderedDesc.
+func (s OrderedAsc[E]) Desc() OrderedDesc[E] {
+	return OrderedDesc[E](s)
+}
+
+// OrderedDesc implements Interface for a slice of an ordered type
+// in descending order.
+//
+// NaN values are ordered after any other values (the reverse of
+// OrderedAsc).
+type OrderedDesc[E cmp.Ordered] []E
+
+// Less implements Interface.
+func (s OrderedDesc[E]) Less(i, j int) bool {
+	return cmp.Less(s[j], s[i])
+}
+
+// Asc returns the same slice (sharing the backing array) as OrderedAsc.
+func (s OrderedDesc[E]) Asc() OrderedAsc[E] {
+	return OrderedAsc[E](s)
+}
@@ -0,0 +1,39 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOrderedDesc(t *testing.T) {
+	asc := OrderedAsc[int]{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}
+	desc := asc.Desc()
+
+	// both views alias the same array
+	desc[0] = 2
+	if asc[0] != 2 {
+		t.Fatalf("the mutation is not visible through OrderedAsc: %v", asc)
+	}
+	if &desc.Asc()[0] != &asc[0] {
+		t.Fatal("Asc returned a copy")
+	}
+
+	Sort(desc)
+	if !intsEqual(asc, []int{13, 12, 11, 8, 7, 6, 5, 4, 3, 2}) {
+		t.Fatalf("unexpected result: %v", asc)
+	}
+	Sort(desc.Asc())
+	if !intsEqual(desc, []int{2, 3, 4, 5, 6, 7, 8, 11, 12, 13}) {
+		t.Fatalf("unexpected result: %v", desc)
+	}
+
+	floats := OrderedDesc[float64]{3, math.NaN(), 1, 2}
+	Sort(floats)
+	if floats[0] != 3 || floats[2] != 1 || !math.IsNaN(floats[3]) {
+		t.Fatalf("unexpected result: %v", floats)
+	}
+}
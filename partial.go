@@ -0,0 +1,146 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+
+	"github.com/go-ng/sort"
+)
+
+// PartialSort rearranges the slice so that `s[:k]` contains the k least
+// elements in sorted order, while `s[k:]` contains the rest of elements
+// in an unspecified order (like `std::partial_sort` in C++).
+//
+// It is faster than a full sort if k is much lower than the length of
+// the slice.
+//
+// T: O(n*ln(k) + k*ln(k))
+//
+// S: O(1)
+func PartialSort[E any, S Interface[E]](s S, k int) {
+	if k < 0 || k > len(s) {
+		panic(fmt.Sprintf("k (%d) is out of range [0, %d]", k, len(s)))
+	}
+	if k == 0 {
+		return
+	}
+	if k == len(s) {
+		sort.Sort(s)
+		return
+	}
+
+	// Strategy:
+	//
+	// Keep the k least elements seen so far in a max-heap in s[:k]. Each
+	// of the remaining elements replaces the root of the heap if it is less
+	// than the root. At the end the heap is sorted in-place (heap sort).
+
+	for idx := k/2 - 1; idx >= 0; idx-- {
+		siftDownMax(s, idx, k)
+	}
+	for idx := k; idx < len(s); idx++ {
+		if !s.Less(idx, 0) {
+			continue
+		}
+		s[0], s[idx] = s[idx], s[0]
+		siftDownMax(s, 0, k)
+	}
+	for idx := k - 1; idx > 0; idx-- {
+		s[0], s[idx] = s[idx], s[0]
+		siftDownMax(s, 0, idx)
+	}
+}
+
+// siftDownMax restores the max-heap property of s[:hi] for the subtree
+// with the root at index root.
+func siftDownMax[E any, S Interface[E]](s S, root, hi int) {
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			return
+		}
+		if child+1 < hi && s.Less(child, child+1) {
+			child++
+		}
+		if !s.Less(root, child) {
+			return
+		}
+		s[root], s[child] = s[child], s[root]
+		root = child
+	}
+}
+
+// Nth rearranges the slice so that `s[n]` contains the element which
+// would be at index n if the slice was sorted (the n-th order statistic),
+// all the elements before it are not greater, and all the elements
+// after it are not less (like `std::nth_element` in C++).
+//
+// T: O(n) on average, O(n^2) in the worst case
+//
+// S: O(1)
+func Nth[E any, S Interface[E]](s S, n int) {
+	if n < 0 || n >= len(s) {
+		panic(fmt.Sprintf("n (%d) is out of range [0, %d)", n, len(s)))
+	}
+
+	// Strategy:
+	//
+	// Quickselect with a median-of-three pivot and a three-way partition
+	// (so that duplicates do not degrade the performance).
+
+	lo, hi := 0, len(s)
+	for hi-lo > 1 {
+		movePivotMedianOfThree(s, lo, hi)
+		eqStart, gt := partitionThreeWay(s, lo, hi)
+
+		switch {
+		case n < eqStart:
+			hi = eqStart
+		case n >= gt:
+			lo = gt
+		default:
+			return
+		}
+	}
+}
+
+// partitionThreeWay partitions s[lo:hi] around the pivot s[lo], so that
+// s[lo:eqStart] < pivot, s[eqStart:gt] == pivot and s[gt:hi] > pivot.
+func partitionThreeWay[E any, S Interface[E]](s S, lo, hi int) (eqStart, gt int) {
+	lt, idx := lo+1, lo+1
+	gt = hi
+	for idx < gt {
+		switch {
+		case s.Less(idx, lo):
+			s[idx], s[lt] = s[lt], s[idx]
+			lt++
+			idx++
+		case s.Less(lo, idx):
+			gt--
+			s[idx], s[gt] = s[gt], s[idx]
+		default:
+			idx++
+		}
+	}
+	s[lo], s[lt-1] = s[lt-1], s[lo]
+	return lt - 1, gt
+}
+
+// movePivotMedianOfThree moves the median of s[lo], s[mid] and s[hi-1]
+// to s[lo].
+func movePivotMedianOfThree[E any, S Interface[E]](s S, lo, hi int) {
+	mid, last := lo+(hi-lo)/2, hi-1
+	if s.Less(mid, lo) {
+		s[mid], s[lo] = s[lo], s[mid]
+	}
+	if s.Less(last, mid) {
+		s[last], s[mid] = s[mid], s[last]
+		if s.Less(mid, lo) {
+			s[mid], s[lo] = s[lo], s[mid]
+		}
+	}
+	s[lo], s[mid] = s[mid], s[lo]
+}
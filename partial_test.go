@@ -0,0 +1,113 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"testing"
+)
+
+func testPartialSort(t *testing.T, initial []byte, k int) {
+	s := make(intSlice, len(initial))
+	for idx, v := range initial {
+		s[idx] = int(v)
+	}
+	expected := make([]int, len(s))
+	copy(expected, s)
+	stdsort.Ints(expected)
+
+	t.Run(fmt.Sprintf("%v (k: %d)", s, k), func(t *testing.T) {
+		PartialSort(s, k)
+		if !intsEqual(expected[:k], s[:k]) {
+			t.Fatalf("%v != %v", expected[:k], s[:k])
+		}
+		all := make([]int, len(s))
+		copy(all, s)
+		stdsort.Ints(all)
+		if !intsEqual(expected, all) {
+			t.Fatalf("the multiset of elements is not preserved: %v != %v", expected, all)
+		}
+	})
+}
+
+func TestPartialSort(t *testing.T) {
+	testPartialSort(t, nil, 0)
+	testPartialSort(t, []byte{3, 1, 2}, 0)
+	testPartialSort(t, []byte{3, 1, 2}, 3)
+	testPartialSort(t, []byte{9, 8, 7, 6, 5, 4, 3, 2, 1}, 3)
+	testPartialSort(t, []byte{1, 1, 0, 5, 0, 1, 2}, 4)
+}
+
+func TestPartialSortInvalidK(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected a panic")
+		}
+	}()
+	PartialSort(intSlice{1, 2}, 3)
+}
+
+func FuzzPartialSort(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial []byte) {
+		testPartialSort(t, initial, rand.Intn(len(initial)+1))
+	})
+}
+
+func testNth(t *testing.T, initial []byte, n int) {
+	s := make(intSlice, len(initial))
+	for idx, v := range initial {
+		s[idx] = int(v)
+	}
+	expected := make([]int, len(s))
+	copy(expected, s)
+	stdsort.Ints(expected)
+
+	t.Run(fmt.Sprintf("%v (n: %d)", s, n), func(t *testing.T) {
+		Nth(s, n)
+		if s[n] != expected[n] {
+			t.Fatalf("%d != %d", s[n], expected[n])
+		}
+		for idx := range s {
+			if (idx < n && s[idx] > s[n]) || (idx > n && s[idx] < s[n]) {
+				t.Fatalf("the slice is not partitioned around %d: %v", n, s)
+			}
+		}
+		all := make([]int, len(s))
+		copy(all, s)
+		stdsort.Ints(all)
+		if !intsEqual(expected, all) {
+			t.Fatalf("the multiset of elements is not preserved: %v != %v", expected, all)
+		}
+	})
+}
+
+func TestNth(t *testing.T) {
+	testNth(t, []byte{3}, 0)
+	testNth(t, []byte{3, 1, 2}, 0)
+	testNth(t, []byte{3, 1, 2}, 2)
+	testNth(t, []byte{9, 8, 7, 6, 5, 4, 3, 2, 1}, 3)
+	testNth(t, []byte{1, 1, 0, 5, 0, 1, 2}, 4)
+	testNth(t, []byte{1, 1, 1, 1, 1, 1, 1}, 4)
+}
+
+func TestNthInvalidN(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected a panic")
+		}
+	}()
+	Nth(intSlice{1, 2}, 2)
+}
+
+func FuzzNth(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial []byte) {
+		if len(initial) == 0 {
+			return
+		}
+		testNth(t, initial, rand.Intn(len(initial)))
+	})
+}
@@ -0,0 +1,38 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+// Partition rearranges the slice so that all the elements satisfying
+// the predicate go first, and returns the index of the first element not
+// satisfying it (`len(s)` if all of them satisfy). It is a building block
+// for selection algorithms (like quickselect).
+//
+// lessThanPivot is called with the current index of an element, exactly
+// once for each element and before the element is moved.
+// The result is not stable.
+//
+// T: O(n)
+//
+// S: O(1)
+func Partition[E any, S Interface[E]](s S, lessThanPivot func(i int) bool) int {
+	lo, hi := 0, len(s)
+	for {
+		for lo < hi && lessThanPivot(lo) {
+			lo++
+		}
+		// s[lo] (if any) does not satisfy the predicate
+		for {
+			if lo >= hi-1 {
+				return lo
+			}
+			hi--
+			if lessThanPivot(hi) {
+				break
+			}
+		}
+		s[lo], s[hi] = s[hi], s[lo]
+		lo++
+	}
+}
@@ -0,0 +1,104 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func testPartition(t *testing.T, initial []byte, pivot byte) {
+	s := make(keySeqs, len(initial))
+	for idx, v := range initial {
+		s[idx] = keySeq{Key: int(v), Seq: idx}
+	}
+	t.Run(fmt.Sprintf("%v/pivot-%d", initial, pivot), func(t *testing.T) {
+		calls := make([]int, len(s))
+		boundary := Partition(s, func(i int) bool {
+			calls[s[i].Seq]++
+			return s[i].Key < int(pivot)
+		})
+
+		for idx, v := range s {
+			if (idx < boundary) != (v.Key < int(pivot)) {
+				t.Fatalf("wrong partition at %d (boundary %d): %v", idx, boundary, s)
+			}
+		}
+		for idx, count := range calls {
+			if count != 1 {
+				t.Fatalf("the predicate is called %d times for the element #%d", count, idx)
+			}
+		}
+		seen := make([]bool, len(s))
+		for _, v := range s {
+			if seen[v.Seq] || v.Key != int(initial[v.Seq]) {
+				t.Fatalf("the elements changed: %v", s)
+			}
+			seen[v.Seq] = true
+		}
+	})
+}
+
+func TestPartition(t *testing.T) {
+	// all true
+	testPartition(t, []byte{5, 1, 3, 2, 4}, 10)
+	// all false
+	testPartition(t, []byte{5, 1, 3, 2, 4}, 0)
+	// mixed
+	testPartition(t, []byte{5, 1, 3, 2, 4}, 3)
+	testPartition(t, []byte{9, 9, 9, 1, 1, 1}, 5)
+	testPartition(t, []byte{1, 9, 1, 9, 1, 9}, 5)
+	testPartition(t, []byte{7}, 5)
+	testPartition(t, nil, 5)
+
+	if boundary := Partition(intSlice{5, 1, 3, 2, 4}, func(int) bool { return true }); boundary != 5 {
+		t.Fatalf("unexpected boundary: %d", boundary)
+	}
+	if boundary := Partition(intSlice{5, 1, 3, 2, 4}, func(int) bool { return false }); boundary != 0 {
+		t.Fatalf("unexpected boundary: %d", boundary)
+	}
+}
+
+func FuzzPartition(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial []byte, pivot byte) {
+		testPartition(t, initial, pivot)
+	})
+}
+
+func BenchmarkPartition(b *testing.B) {
+	const (
+		totalSize = 65536
+		csCount   = 20
+	)
+	rng := rand.New(rand.NewSource(0))
+	in := make([][]int, csCount)
+	for idx := range in {
+		in[idx] = make([]int, totalSize)
+		for i := range in[idx] {
+			in[idx][i] = rng.Intn(totalSize)
+		}
+	}
+	cs := make([]intSlice, csCount)
+	for idx := range cs {
+		cs[idx] = make([]int, totalSize)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		idx := i % csCount
+		if idx == 0 {
+			b.StopTimer()
+			for idx := range cs {
+				copy(cs[idx], in[idx])
+			}
+			b.StartTimer()
+		}
+		s := cs[idx]
+		Partition(s, func(i int) bool {
+			return s[i] < totalSize/2
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+
+	"github.com/go-ng/slices"
+	"github.com/go-ng/sort"
+)
+
+// AppendedPreSortedTail is the same as Appended, but it assumes the tail
+// s[len(s)-tailLength:] is already sorted in ascending order (for example
+// if the same pre-sorted values are appended to different slices), so
+// the tail is not sorted again.
+//
+// It panics if the tail is not sorted (this is checked in O(k)). Since
+// the tail is not sorted again, the optimization is used for ~10% longer
+// tails than in Appended (see sortedTailDiscountDivisor).
+//
+// T: O(k*ln(n) + n + k^2) -- thus if `k` is too high then: O(k^2)
+//
+// S: O(1) [if without `s`]
+func AppendedPreSortedTail[E any, S Interface[E]](s S, tailLength uint) {
+	checkTailLength(len(s), tailLength)
+	splitIdx := uint(len(s)) - tailLength
+	for idx := int(splitIdx) + 1; idx < len(s); idx++ {
+		if s.Less(idx, idx-1) {
+			panic(fmt.Sprintf("the tail is not sorted: elements %d and %d are in the wrong order", idx-1, idx))
+		}
+	}
+
+	switch startAppended(s, tailLength, appendedPolicy{sortedTail: true}) {
+	case StrategyFallbackSort:
+		if splitIdx == 0 {
+			// the whole slice is the sorted tail
+			return
+		}
+		sort.Sort(s)
+	case StrategyGroupInsert:
+		slices.Reverse(s[splitIdx:])
+		groupInsertDescendingTail(s, splitIdx)
+	}
+}
@@ -0,0 +1,87 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"math/bits"
+
+	"github.com/go-ng/sort"
+)
+
+// progressSteps is the approximate amount of calls of the progress
+// callback of AppendedWithProgress.
+const progressSteps = 100
+
+// AppendedWithProgress is the same as Appended, but it periodically
+// calls progress (if not nil) with the amount of work done so far and
+// the total amount of work (in arbitrary units), for example to drive
+// a progress bar. The last call is always with `done == total`.
+//
+// The group-insert reports after each 1% of the inserted tail elements.
+// If the optimization is not applicable, then instead of a single sort
+// the slice is sorted in chunks, which are merged afterwards (see
+// ChunkSortMerge): the progress is reported after each sorted chunk
+// and after each round of merges.
+//
+// The callback is called from the same goroutine.
+//
+// T: the same as Appended
+//
+// S: O(1) [if without `s`]; O(n) if the optimization is not applicable
+func AppendedWithProgress[E any, S Interface[E]](s S, tailLength uint, progress func(done, total int)) {
+	if progress == nil {
+		Appended(s, tailLength)
+		return
+	}
+	strategy := startAppended(s, tailLength, appendedPolicy{})
+	switch strategy {
+	case StrategyAlreadySorted:
+		return
+	case StrategyFallbackSort:
+		chunkSortWithProgress(s, progress)
+		return
+	}
+
+	step := tailLength / progressSteps
+	if step == 0 {
+		step = 1
+	}
+	finishAppendedSeq(stdInterface[E, S](s), tailLength, strategy, func(_ string, inserted uint) {
+		if inserted%step == 0 && inserted < tailLength {
+			progress(int(inserted), int(tailLength))
+		}
+	})
+	progress(int(tailLength), int(tailLength))
+}
+
+// chunkSortWithProgress sorts the slice in progressSteps chunks and merges
+// them, calling progress after each chunk and after each round of merges.
+// The total amount of work is the length of the slice per each phase.
+func chunkSortWithProgress[E any, S Interface[E]](s S, progress func(done, total int)) {
+	chunkSize := (len(s) + progressSteps - 1) / progressSteps
+	chunks := (len(s) + chunkSize - 1) / chunkSize
+	rounds := bits.Len(uint(chunks - 1))
+	total := len(s) * (1 + rounds)
+
+	bounds := make([]int, 1, chunks+1)
+	for start := 0; start < len(s); start += chunkSize {
+		end := start + chunkSize
+		if end > len(s) {
+			end = len(s)
+		}
+		sort.Sort(s[start:end])
+		bounds = append(bounds, end)
+		progress(end, total)
+	}
+	if len(bounds) <= 2 {
+		return
+	}
+
+	done := len(s)
+	mergeRunsPairwise(s, bounds, func() {
+		done += len(s)
+		progress(done, total)
+	})
+}
@@ -0,0 +1,119 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+func testAppendedWithProgress(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		var calls, prevDone, prevTotal int
+		AppendedWithProgress(intSlice(s), tailLenght, func(done, total int) {
+			calls++
+			if done <= prevDone || done > total || (prevTotal != 0 && total != prevTotal) {
+				t.Fatalf("unexpected progress: %d/%d after %d/%d", done, total, prevDone, prevTotal)
+			}
+			prevDone, prevTotal = done, total
+		})
+		if tailLenght > 0 && (calls == 0 || prevDone != prevTotal) {
+			t.Fatalf("the last progress is not complete: %d/%d (calls: %d)", prevDone, prevTotal, calls)
+		}
+		if calls > progressSteps*2 {
+			t.Fatalf("too many calls: %d", calls)
+		}
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedWithProgress(t *testing.T) {
+	testAppendedWithProgress(t, []byte{}, 0)
+	testAppendedWithProgress(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedWithProgress(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedWithProgress(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 14, 12, 8, 1}, 4)
+	testAppendedWithProgress(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 16, 18, 20, 21}, 4)
+	testAppendedWithProgress(t, []byte{5, 4, 3, 2, 1}, 5)
+
+	for _, tailLength := range []uint{1, 100, 1000, 10000} {
+		initial := make([]byte, 20000)
+		rand.New(rand.NewSource(int64(tailLength))).Read(initial)
+		testAppendedWithProgress(t, initial, tailLength)
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		s := intSlice{1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 21, 23, 25, 27, 29, 31, 10, 2}
+		AppendedWithProgress(s, 2, nil)
+		if !stdsort.IntsAreSorted(s) {
+			t.Fatalf("not sorted: %v", s)
+		}
+	})
+}
+
+func FuzzAppendedWithProgress(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedWithProgress(t, initial, tailLenght)
+	})
+}
+
+func BenchmarkAppendedWithProgress(b *testing.B) {
+	const (
+		totalSize = 65536
+		csCount   = 20
+	)
+	for _, tailSize := range []int{1024, 16384} {
+		rng := rand.New(rand.NewSource(0))
+		in := make([][]int, csCount)
+		for idx := range in {
+			in[idx] = make([]int, totalSize)
+			s := in[idx]
+			for idx := range s {
+				s[idx] = rng.Intn(totalSize)
+			}
+			stdsort.Ints(s[:totalSize-tailSize])
+		}
+
+		cs := make([]intSlice, csCount)
+		for idx := range cs {
+			cs[idx] = make([]int, totalSize)
+		}
+
+		for _, f := range []struct {
+			name string
+			fn   func(intSlice, uint)
+		}{
+			{name: "Appended", fn: Appended[int, intSlice]},
+			{name: "AppendedWithProgress", fn: func(s intSlice, tailLength uint) {
+				AppendedWithProgress(s, tailLength, func(done, total int) {})
+			}},
+		} {
+			b.Run(fmt.Sprintf("total-%d/tail-%d/%s", totalSize, tailSize, f.name), func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range cs {
+							copy(cs[idx], in[idx])
+						}
+						b.StartTimer()
+					}
+					f.fn(cs[idx], uint(tailSize))
+				}
+			})
+		}
+	}
+}
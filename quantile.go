@@ -0,0 +1,87 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+)
+
+// Number is a constraint of integer and floating-point types.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Quantile returns the q-quantile (q is in range [0, 1]) of an already
+// sorted slice: the element at index `round(q*(len(sorted)-1))`. For
+// example, `Quantile(latencies, 0.99)` is the 99th percentile.
+//
+// It panics if the slice is empty or if q is out of range. See also
+// QuantileLinear for the linear interpolation between the neighbouring
+// elements.
+//
+// T: O(1)
+//
+// S: O(1)
+func Quantile[E cmp.Ordered](sorted []E, q float64) E {
+	return sorted[quantileIdx(len(sorted), q)]
+}
+
+// QuantileLinear is the same as Quantile, but it linearly interpolates
+// between the elements at indexes `floor(q*(len(sorted)-1))` and
+// `ceil(q*(len(sorted)-1))` (like the default method of NumPy).
+//
+// T: O(1)
+//
+// S: O(1)
+func QuantileLinear[E Number](sorted []E, q float64) float64 {
+	checkQuantile(len(sorted), q)
+	pos := q * float64(len(sorted)-1)
+	lowerIdx := int(pos)
+	if lowerIdx == len(sorted)-1 {
+		return float64(sorted[lowerIdx])
+	}
+	lower, upper := float64(sorted[lowerIdx]), float64(sorted[lowerIdx+1])
+	return lower + (pos-float64(lowerIdx))*(upper-lower)
+}
+
+// Percentiles returns the percentiles ps (each is in range [0, 100]) of
+// an already sorted slice (see Quantile). For example,
+// `Percentiles(latencies, []float64{50, 90, 99})`.
+//
+// T: O(p)
+//
+// S: O(p)
+func Percentiles[E cmp.Ordered](sorted []E, ps []float64) []E {
+	result := make([]E, len(ps))
+	for idx, p := range ps {
+		if !(p >= 0 && p <= 100) {
+			panic(fmt.Sprintf("percentile #%d (%v) is out of range [0, 100]", idx, p))
+		}
+		result[idx] = Quantile(sorted, p/100)
+	}
+	return result
+}
+
+// quantileIdx returns the index of the q-quantile in a sorted slice of
+// the given length.
+func quantileIdx(length int, q float64) int {
+	checkQuantile(length, q)
+	return int(math.Round(q * float64(length-1)))
+}
+
+// checkQuantile panics if the q-quantile is not defined for a slice of
+// the given length.
+func checkQuantile(length int, q float64) {
+	if length == 0 {
+		panic("the quantile of an empty slice is not defined")
+	}
+	if !(q >= 0 && q <= 1) {
+		panic(fmt.Sprintf("q (%v) is out of range [0, 1]", q))
+	}
+}
@@ -0,0 +1,120 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	stdsort "sort"
+	"testing"
+)
+
+func TestQuantile(t *testing.T) {
+	s := []int{10, 20, 30, 40, 50}
+	for _, testCase := range []struct {
+		q              float64
+		expected       int
+		expectedLinear float64
+	}{
+		{0, 10, 10},
+		{0.1, 10, 14},
+		{0.125, 20, 15},
+		{0.5, 30, 30},
+		{0.9, 50, 46},
+		{1, 50, 50},
+	} {
+		t.Run(fmt.Sprint(testCase.q), func(t *testing.T) {
+			if result := Quantile(s, testCase.q); result != testCase.expected {
+				t.Fatalf("Quantile: %v != %v", result, testCase.expected)
+			}
+			if result := QuantileLinear(s, testCase.q); math.Abs(result-testCase.expectedLinear) > 1e-9 {
+				t.Fatalf("QuantileLinear: %v != %v", result, testCase.expectedLinear)
+			}
+		})
+	}
+
+	t.Run("single_element", func(t *testing.T) {
+		for _, q := range []float64{0, 0.5, 1} {
+			if result := Quantile([]string{"a"}, q); result != "a" {
+				t.Fatalf("%q != %q", result, "a")
+			}
+			if result := QuantileLinear([]uint8{7}, q); result != 7 {
+				t.Fatalf("%v != 7", result)
+			}
+		}
+	})
+
+	t.Run("Percentiles", func(t *testing.T) {
+		s := make([]int, 101)
+		for idx := range s {
+			s[idx] = idx * 10
+		}
+		result := Percentiles(s, []float64{0, 50, 99, 99.9, 100})
+		if !Equal(result, []int{0, 500, 990, 1000, 1000}) {
+			t.Fatalf("unexpected percentiles: %v", result)
+		}
+	})
+
+	for _, testCase := range []struct {
+		name string
+		fn   func()
+	}{
+		{"empty", func() { Quantile([]int{}, 0.5) }},
+		{"empty_linear", func() { QuantileLinear([]int{}, 0.5) }},
+		{"negative", func() { Quantile(s, -0.1) }},
+		{"greater_than_one", func() { QuantileLinear(s, 1.1) }},
+		{"NaN", func() { Quantile(s, math.NaN()) }},
+		{"percentile", func() { Percentiles(s, []float64{50, 101}) }},
+	} {
+		t.Run("panic_"+testCase.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("expected a panic")
+				}
+			}()
+			testCase.fn()
+		})
+	}
+}
+
+func FuzzQuantile(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		if len(initial) == 0 {
+			return
+		}
+		s := append([]byte{}, initial...)
+		stdsort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+		q := rand.Float64()
+
+		v := Quantile(s, q)
+		lessCount := stdsort.Search(len(s), func(i int) bool { return s[i] >= v })
+		notGreaterCount := stdsort.Search(len(s), func(i int) bool { return s[i] > v })
+		idx := int(math.Round(q * float64(len(s)-1)))
+		if idx < lessCount || idx >= notGreaterCount {
+			t.Fatalf("%v is not the %v-quantile of %v", v, q, s)
+		}
+
+		linear := QuantileLinear(s, q)
+		lower, upper := float64(s[int(q*float64(len(s)-1))]), float64(s[int(math.Ceil(q*float64(len(s)-1)))])
+		if linear < lower || linear > upper {
+			t.Fatalf("%v is not in range [%v, %v]", linear, lower, upper)
+		}
+	})
+}
+
+func BenchmarkPercentiles(b *testing.B) {
+	s := make([]float64, 65536)
+	for idx := range s {
+		s[idx] = rand.Float64()
+	}
+	stdsort.Float64s(s)
+	ps := []float64{50, 90, 99, 99.9}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Percentiles(s, ps)
+	}
+}
@@ -0,0 +1,84 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+// quickSortInsertionThreshold is the length of a range, below which
+// quickSortRandomized uses the insertion sort.
+const quickSortInsertionThreshold = 12
+
+// AppendedWithSeed is the same as Appended, but all the sorting (of the
+// tail and the fallback full sort) is done by a quicksort with
+// pseudo-random pivots, generated from the given seed. This protects
+// from crafted inputs causing the quadratic worst case of a quicksort with
+// a deterministic pivot selection. The result is reproducible for the same
+// seed.
+//
+// T: O(k*ln(n) + n + k^2) -- thus if `k` is too high then: O(n*ln(n))
+// on average (for any input).
+//
+// S: O(ln(n)) [if without `s`]
+func AppendedWithSeed[E any, S Interface[E]](s S, tailLength uint, seed int64) {
+	strategy := startAppended(s, tailLength, appendedPolicy{})
+	rng := splitMix64(seed)
+	splitIdx := uint(len(s)) - tailLength
+	switch strategy {
+	case StrategyFallbackSort:
+		quickSortRandomized(s, &rng)
+	case StrategySortTail:
+		quickSortRandomized(s[splitIdx:], &rng)
+	case StrategyGroupInsert:
+		quickSortRandomized(descending[E, S](s[splitIdx:]), &rng)
+		groupInsertDescendingTail(s, splitIdx)
+	}
+}
+
+// quickSortRandomized sorts the slice using a quicksort with
+// a three-way partition. The pivots are chosen by rng, or using
+// the median of three if rng is nil.
+func quickSortRandomized[E any, S Interface[E]](s S, rng *splitMix64) {
+	lo, hi := 0, len(s)
+	for hi-lo > quickSortInsertionThreshold {
+		if rng != nil {
+			pivotIdx := lo + rng.intn(hi-lo)
+			s[lo], s[pivotIdx] = s[pivotIdx], s[lo]
+		} else {
+			movePivotMedianOfThree(s, lo, hi)
+		}
+		eqStart, gt := partitionThreeWay(s, lo, hi)
+
+		// recurse into the smaller part to keep the stack O(ln(n))
+		if eqStart-lo < hi-gt {
+			quickSortRandomized(s[lo:eqStart], rng)
+			lo = gt
+		} else {
+			quickSortRandomized(s[gt:hi], rng)
+			hi = eqStart
+		}
+	}
+	insertionSort(s[lo:hi])
+}
+
+// insertionSort sorts the slice using the insertion sort.
+func insertionSort[E any, S Interface[E]](s S) {
+	for i := 1; i < len(s); i++ {
+		for j := i; j > 0 && s.Less(j, j-1); j-- {
+			s[j], s[j-1] = s[j-1], s[j]
+		}
+	}
+}
+
+// splitMix64 is a tiny pseudo-random numbers generator (SplitMix64). It is
+// used instead of `math/rand` to avoid allocations.
+type splitMix64 uint64
+
+// intn returns a pseudo-random number in [0, n).
+func (r *splitMix64) intn(n int) int {
+	*r += 0x9e3779b97f4a7c15
+	z := uint64(*r)
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	z ^= z >> 31
+	return int(z % uint64(n))
+}
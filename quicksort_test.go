@@ -0,0 +1,184 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/bits"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+// antiQuickSort is the McIlroy's adversary ("A Killer Adversary for
+// Quicksort"): it decides the values of the elements lazily during
+// the comparisons, making the pivots as bad as possible.
+type antiQuickSort struct {
+	values    []int
+	gas       int
+	solid     int
+	candidate int
+}
+
+type antiQuickSortElem struct {
+	id  int
+	adv *antiQuickSort
+}
+
+type antiQuickSortSlice []antiQuickSortElem
+
+func (s antiQuickSortSlice) Less(i, j int) bool {
+	adv := s[i].adv
+	x, y := s[i].id, s[j].id
+	if adv.values[x] == adv.gas && adv.values[y] == adv.gas {
+		if x == adv.candidate {
+			adv.freeze(x)
+		} else {
+			adv.freeze(y)
+		}
+	}
+	switch {
+	case adv.values[x] == adv.gas:
+		adv.candidate = x
+	case adv.values[y] == adv.gas:
+		adv.candidate = y
+	}
+	return adv.values[x] < adv.values[y]
+}
+
+func (adv *antiQuickSort) freeze(id int) {
+	adv.values[id] = adv.solid
+	adv.solid++
+}
+
+// quickSortKiller returns a sequence of length n, which causes
+// the quadratic behavior of quickSortRandomized with the median-of-three
+// pivot (no rng).
+func quickSortKiller(n int) []int {
+	adv := &antiQuickSort{values: make([]int, n), gas: n}
+	s := make(antiQuickSortSlice, n)
+	for idx := range s {
+		adv.values[idx] = adv.gas
+		s[idx] = antiQuickSortElem{id: idx, adv: adv}
+	}
+	quickSortRandomized(s, nil)
+	return adv.values
+}
+
+func testAppendedWithSeed(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		AppendedWithSeed(intSlice(s), tailLenght, 1)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedWithSeed(t *testing.T) {
+	testAppendedWithSeed(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedWithSeed(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedWithSeed(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 16, 18, 20, 21}, 4)
+	testAppendedWithSeed(t, []byte{5, 4, 3, 2, 1, 5, 4, 3, 2, 1, 5, 4, 3, 2, 1, 0, 9}, 17)
+
+	t.Run("quicksort_killer", func(t *testing.T) {
+		const n = 2000
+		killer := quickSortKiller(n)
+		nLogN := n * bits.Len(n)
+
+		s, count := newCountedInts(killer)
+		quickSortRandomized(s, nil)
+		if *count < n*n/16 {
+			t.Fatalf("the killer sequence is expected to be quadratic for median-of-three pivots, but only %d comparisons were made", *count)
+		}
+
+		for _, seed := range []int64{0, 1, 2} {
+			s, count := newCountedInts(killer)
+			AppendedWithSeed(s, n, seed)
+			for idx := 1; idx < len(s); idx++ {
+				if s[idx].v < s[idx-1].v {
+					t.Fatalf("not sorted (seed %d) at index %d", seed, idx)
+				}
+			}
+			if *count > 4*nLogN {
+				t.Fatalf("too many comparisons (seed %d): %d > %d", seed, *count, 4*nLogN)
+			}
+		}
+	})
+
+	t.Run("reproducible", func(t *testing.T) {
+		// the order of equal elements depends only on the seed
+		keySeqs0, keySeqs1 := randomKeySeqs(), randomKeySeqs()
+		copy(keySeqs1, keySeqs0)
+		AppendedWithSeed(keySeqs0, uint(len(keySeqs0)), 42)
+		AppendedWithSeed(keySeqs1, uint(len(keySeqs1)), 42)
+		for idx := range keySeqs0 {
+			if keySeqs0[idx] != keySeqs1[idx] {
+				t.Fatalf("different results at index %d: %v != %v", idx, keySeqs0[idx], keySeqs1[idx])
+			}
+		}
+	})
+}
+
+func FuzzAppendedWithSeed(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedWithSeed(t, initial, tailLenght)
+	})
+}
+
+func BenchmarkAppendedWithSeed(b *testing.B) {
+	const (
+		totalSize = 65536
+		csCount   = 20
+	)
+	for _, tailSize := range []int{16, 1024, 65536} {
+		rng := rand.New(rand.NewSource(0))
+		in := make([][]int, csCount)
+		for idx := range in {
+			in[idx] = make([]int, totalSize)
+			s := in[idx]
+			for idx := range s {
+				s[idx] = rng.Intn(totalSize)
+			}
+			stdsort.Ints(s[:totalSize-tailSize])
+		}
+
+		cs := make([]intSlice, csCount)
+		for idx := range cs {
+			cs[idx] = make([]int, totalSize)
+		}
+
+		for _, f := range []struct {
+			name string
+			fn   func(intSlice, uint)
+		}{
+			{name: "Appended", fn: Appended[int, intSlice]},
+			{name: "AppendedWithSeed", fn: func(s intSlice, tailLength uint) {
+				AppendedWithSeed(s, tailLength, 1)
+			}},
+		} {
+			b.Run(fmt.Sprintf("total-%d/tail-%d/%s", totalSize, tailSize, f.name), func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range cs {
+							copy(cs[idx], in[idx])
+						}
+						b.StartTimer()
+					}
+					f.fn(cs[idx], uint(tailSize))
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,204 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+
+	"github.com/go-ng/slices"
+	"github.com/go-ng/sort"
+)
+
+// The labels of the operations in Recorder.
+const (
+	// RecordedLess is a call of Less.
+	RecordedLess = "less"
+
+	// RecordedSwap is a swap of two elements.
+	RecordedSwap = "swap"
+
+	// RecordedRotate is a rotation of the range [I, J) by Shift (the same
+	// as `slices.Rotate(s[I:J], Shift)`).
+	RecordedRotate = "rotate"
+
+	// RecordedReverse is a reversal of the range [I, J).
+	RecordedReverse = "reverse"
+)
+
+// RecordedOp is a single operation recorded by AppendedRecorded.
+type RecordedOp struct {
+	// Op is the label of the operation (see the Recorded* constants).
+	Op string
+
+	// I and J are the indexes of the compared (or swapped) elements, or
+	// the range of a rotation or a reversal.
+	I, J int
+
+	// Shift is the shift of a rotation (zero for other operations).
+	Shift int
+
+	// Result is the result of Less (false for other operations).
+	Result bool
+}
+
+// Recorder contains the operations performed by AppendedRecorded. It is
+// a debugging tool: it allows to reproduce a mis-sort (for example caused
+// by an inconsistent Less) without the original comparator.
+//
+// All the fields are exported, so a Recorder could be dumped and loaded
+// using any encoding (like `encoding/json`).
+type Recorder struct {
+	TotalSize  int
+	TailLength uint
+	Ops        []RecordedOp
+}
+
+// AppendedRecorded is the same as Appended (it runs exactly the same code
+// path), but all the results of Less and all the swaps, rotations and
+// reversals are recorded into rec (overwriting its previous content).
+// The sequence could be reproduced by Replay.
+//
+// The moves of elements inside the sorting of the tail (or of the whole
+// slice) are not recorded: they are fully defined by the recorded results
+// of Less.
+//
+// Appended itself has no recording overhead.
+//
+// T: the same as Appended
+//
+// S: O(the amount of operations)
+func AppendedRecorded[E any, S Interface[E]](s S, tailLength uint, rec *Recorder) {
+	rec.TotalSize = len(s)
+	rec.TailLength = tailLength
+	rec.Ops = rec.Ops[:0]
+	appendedSeq(&recordingSeq[E, S]{
+		s:   s,
+		rec: rec,
+	}, tailLength)
+}
+
+// Replay repeats the sequence of operations recorded by AppendedRecorded
+// on s, which should contain the same elements as the slice passed to
+// AppendedRecorded (in the same order). Less of s is never called:
+// the recorded results are used instead, so the result is exactly
+// the same as the recorded one even if the comparator is inconsistent.
+//
+// It panics if the recorded sequence does not match the slice (for
+// example if the length differs or the recording is truncated).
+func Replay[E any, S Interface[E]](s S, rec *Recorder) {
+	if len(s) != rec.TotalSize {
+		panic(fmt.Sprintf("the length of the slice (%d) differs from the recorded one (%d)", len(s), rec.TotalSize))
+	}
+	r := &replayingSeq[E, S]{
+		s:   s,
+		rec: rec,
+	}
+	appendedSeq(r, rec.TailLength)
+	if r.pos != len(rec.Ops) {
+		panic(fmt.Sprintf("only %d of %d recorded operations were replayed", r.pos, len(rec.Ops)))
+	}
+}
+
+// recordingSeq is a sequence recording the operations on s into rec.
+type recordingSeq[E any, S Interface[E]] struct {
+	s   S
+	rec *Recorder
+}
+
+func (r *recordingSeq[E, S]) Len() int {
+	return len(r.s)
+}
+
+func (r *recordingSeq[E, S]) Less(i, j int) bool {
+	result := r.s.Less(i, j)
+	r.rec.Ops = append(r.rec.Ops, RecordedOp{Op: RecordedLess, I: i, J: j, Result: result})
+	return result
+}
+
+func (r *recordingSeq[E, S]) Swap(i, j int) {
+	r.rec.Ops = append(r.rec.Ops, RecordedOp{Op: RecordedSwap, I: i, J: j})
+	r.s[i], r.s[j] = r.s[j], r.s[i]
+}
+
+func (r *recordingSeq[E, S]) Rotate(a, b, shift int) {
+	r.rec.Ops = append(r.rec.Ops, RecordedOp{Op: RecordedRotate, I: a, J: b, Shift: shift})
+	slices.Rotate(r.s[a:b], shift)
+}
+
+func (r *recordingSeq[E, S]) Reverse(a, b int) {
+	r.rec.Ops = append(r.rec.Ops, RecordedOp{Op: RecordedReverse, I: a, J: b})
+	slices.Reverse(r.s[a:b])
+}
+
+func (r *recordingSeq[E, S]) Sort(a, b int) {
+	// sort.Slice is the same algorithm as sort.Sort (used by
+	// stdInterface), thus the comparisons are the same as in Appended.
+	sort.Slice(r.s[a:b], func(i, j int) bool {
+		return r.Less(a+i, a+j)
+	})
+}
+
+func (r *recordingSeq[E, S]) SortDescending(a, b int) {
+	sort.Slice(r.s[a:b], func(i, j int) bool {
+		return r.Less(a+j, a+i)
+	})
+}
+
+// replayingSeq is a sequence taking the results of Less from rec (and
+// checking that the operations match the recorded ones).
+type replayingSeq[E any, S Interface[E]] struct {
+	s   S
+	rec *Recorder
+	pos int
+}
+
+func (r *replayingSeq[E, S]) Len() int {
+	return len(r.s)
+}
+
+func (r *replayingSeq[E, S]) Less(i, j int) bool {
+	return r.next(RecordedLess, i, j, 0).Result
+}
+
+func (r *replayingSeq[E, S]) Swap(i, j int) {
+	r.next(RecordedSwap, i, j, 0)
+	r.s[i], r.s[j] = r.s[j], r.s[i]
+}
+
+func (r *replayingSeq[E, S]) Rotate(a, b, shift int) {
+	r.next(RecordedRotate, a, b, shift)
+	slices.Rotate(r.s[a:b], shift)
+}
+
+func (r *replayingSeq[E, S]) Reverse(a, b int) {
+	r.next(RecordedReverse, a, b, 0)
+	slices.Reverse(r.s[a:b])
+}
+
+func (r *replayingSeq[E, S]) Sort(a, b int) {
+	sort.Slice(r.s[a:b], func(i, j int) bool {
+		return r.Less(a+i, a+j)
+	})
+}
+
+func (r *replayingSeq[E, S]) SortDescending(a, b int) {
+	sort.Slice(r.s[a:b], func(i, j int) bool {
+		return r.Less(a+j, a+i)
+	})
+}
+
+// next returns the next recorded operation, it panics if it does not
+// match the given one.
+func (r *replayingSeq[E, S]) next(op string, i, j, shift int) RecordedOp {
+	if r.pos >= len(r.rec.Ops) {
+		panic(fmt.Sprintf("the recording is exhausted at %s(%d, %d)", op, i, j))
+	}
+	recorded := r.rec.Ops[r.pos]
+	if recorded.Op != op || recorded.I != i || recorded.J != j || recorded.Shift != shift {
+		panic(fmt.Sprintf("operation #%d: %s(%d, %d, %d) was recorded, but %s(%d, %d, %d) is replayed", r.pos, recorded.Op, recorded.I, recorded.J, recorded.Shift, op, i, j, shift))
+	}
+	r.pos++
+	return recorded
+}
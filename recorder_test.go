@@ -0,0 +1,125 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"encoding/json"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+// flakyInts is a slice with an inconsistent Less.
+type flakyInts []int
+
+func (s flakyInts) Less(i, j int) bool {
+	return rand.Intn(2) == 0
+}
+
+func testAppendedRecorded(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		var rec Recorder
+		AppendedRecorded(intSlice(s), tailLenght, &rec)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedRecorded(t *testing.T) {
+	testAppendedRecorded(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedRecorded(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+
+	rng := rand.New(rand.NewSource(0))
+	initial := make([]int, 1000)
+	for idx := range initial {
+		initial[idx] = rng.Intn(100)
+	}
+	stdsort.Ints(initial[:990])
+
+	t.Run("replay", func(t *testing.T) {
+		s := append(flakyInts{}, initial...)
+		var rec Recorder
+		AppendedRecorded(s, 10, &rec)
+		if len(rec.Ops) == 0 {
+			t.Fatal("nothing was recorded")
+		}
+
+		// the dump is loaded back
+		dump, err := json.Marshal(rec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var loaded Recorder
+		if err := json.Unmarshal(dump, &loaded); err != nil {
+			t.Fatal(err)
+		}
+
+		// the comparator is inconsistent, so the result is reproducible
+		// only by a replay
+		replayed := append(flakyInts{}, initial...)
+		Replay(replayed, &loaded)
+		if !intsEqual(s, replayed) {
+			t.Fatalf("%v != %v", s, replayed)
+		}
+	})
+
+	t.Run("the same as Appended", func(t *testing.T) {
+		s, lessCalls := newCountedInts(initial)
+		Appended(s, 10)
+
+		recorded := append(intSlice{}, initial...)
+		var rec Recorder
+		AppendedRecorded(recorded, 10, &rec)
+		counts := map[string]int{}
+		for _, op := range rec.Ops {
+			counts[op.Op]++
+		}
+		if counts[RecordedLess] != *lessCalls {
+			t.Fatalf("%d != %d", counts[RecordedLess], *lessCalls)
+		}
+		if counts[RecordedRotate] == 0 {
+			t.Fatalf("no rotations were recorded: %v", counts)
+		}
+		for idx := range recorded {
+			if s[idx].v != recorded[idx] {
+				t.Fatalf("different results at %d", idx)
+			}
+		}
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		var rec Recorder
+		AppendedRecorded(append(intSlice{}, initial...), 10, &rec)
+
+		expectPanic(t, "differs from the recorded one", func() {
+			Replay(append(intSlice{}, initial[1:]...), &rec)
+		})
+
+		truncated := rec
+		truncated.Ops = rec.Ops[:len(rec.Ops)/2]
+		expectPanic(t, "the recording is exhausted", func() {
+			Replay(append(intSlice{}, initial...), &truncated)
+		})
+
+		tailChanged := rec
+		tailChanged.TailLength++
+		expectPanic(t, "is replayed", func() {
+			Replay(append(intSlice{}, initial...), &tailChanged)
+		})
+	})
+}
+
+func FuzzAppendedRecorded(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedRecorded(t, initial, tailLenght)
+	})
+}
@@ -0,0 +1,15 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+// AppendedReport is the same as Appended, but it returns false if it fell
+// back to sorting the whole slice (see StrategyFallbackSort), and true
+// otherwise (including the cases when only the tail was sorted or
+// the tail is empty).
+//
+// It is a lightweight alternative to OnStrategy for tests and metrics.
+func AppendedReport[E any, S Interface[E]](s S, tailLength uint) bool {
+	return appendedWithStrategy(s, tailLength) != StrategyFallbackSort
+}
@@ -0,0 +1,97 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+func testAppendedReport(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		expected := chooseAppendedStrategy(intSlice(s), tailLenght) != StrategyFallbackSort
+		if r := AppendedReport(intSlice(s), tailLenght); r != expected {
+			t.Fatalf("unexpected result: %t", r)
+		}
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedReport(t *testing.T) {
+	testAppendedReport(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedReport(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+
+	const totalSize = 65536
+	// the prefix is [tailLength, totalSize), and the tail is
+	// [0, tailLength) in the reverse order, so the tail cannot
+	// be just sorted in place
+	prepare := func(tailLength int) intSlice {
+		s := make(intSlice, totalSize)
+		for idx := range s[:totalSize-tailLength] {
+			s[idx] = tailLength + idx
+		}
+		for idx := totalSize - tailLength; idx < totalSize; idx++ {
+			s[idx] = totalSize - 1 - idx
+		}
+		return s
+	}
+
+	maxTailLength := 0
+	for shouldUseAppended(totalSize, uint(maxTailLength+1)) {
+		maxTailLength++
+	}
+	if maxTailLength == 0 {
+		t.Fatal("the optimization is never used")
+	}
+	for _, testCase := range []struct {
+		tailLength int
+		expected   bool
+	}{
+		{tailLength: 0, expected: true},
+		{tailLength: 1, expected: true},
+		{tailLength: maxTailLength, expected: true},
+		{tailLength: maxTailLength + 1, expected: false},
+		{tailLength: totalSize, expected: false},
+	} {
+		s := prepare(testCase.tailLength)
+		if r := AppendedReport(s, uint(testCase.tailLength)); r != testCase.expected {
+			t.Errorf("tailLength %d: unexpected result: %t", testCase.tailLength, r)
+		}
+		for idx := range s {
+			if s[idx] != idx {
+				t.Fatalf("tailLength %d: not sorted at %d", testCase.tailLength, idx)
+			}
+		}
+	}
+
+	t.Run("sort_tail", func(t *testing.T) {
+		s := prepare(0)
+		s[totalSize-2], s[totalSize-1] = s[totalSize-1], s[totalSize-2]
+		if chooseAppendedStrategy(s, 2) != StrategySortTail {
+			t.Fatal("only the tail is expected to be sorted")
+		}
+		if !AppendedReport(s, 2) {
+			t.Fatal("unexpected result: false")
+		}
+		if !stdsort.IntsAreSorted(s) {
+			t.Fatalf("not sorted")
+		}
+	})
+}
+
+func FuzzAppendedReport(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedReport(t, initial, tailLenght)
+	})
+}
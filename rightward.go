@@ -0,0 +1,74 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"github.com/go-ng/slices"
+	"github.com/go-ng/sort"
+)
+
+// AppendedRightward is the same as Appended, but it merges the tail
+// into the prefix starting from the right end: the greatest elements of
+// the prefix are moved rightward (into the space freed by the tail) and
+// all the tail elements with the same insertion position are placed by
+// a single rotation.
+//
+// It makes less rotations than Appended if the tail values are clustered
+// (for example if all of them are less than the prefix).
+//
+// T: O(k*ln(n) + n + k*g) -- where `g` is the amount of distinct insertion
+// positions of the tail elements (g <= k)
+//
+// S: O(1) [if without `s`]
+func AppendedRightward[E any, S Interface[E]](s S, tailLength uint) {
+	strategy := startAppended(s, tailLength, appendedPolicy{})
+	if strategy != StrategyGroupInsert {
+		finishAppended(s, tailLength, strategy)
+		return
+	}
+	groupInsertAppendSortRightward(s, tailLength)
+}
+
+func groupInsertAppendSortRightward[E any, S Interface[E]](s S, tailLength uint) {
+	// Strategy:
+	//
+	// The layout is `A B`, where A is the not yet merged part of the prefix
+	// and B is the not yet merged part of the (sorted) tail; everything
+	// to the right of B is already in its final position.
+	//
+	// Find the position p in A, where the last element of B should be
+	// inserted. Then all the elements of B not less than A[p-1] should be
+	// inserted at p as well. The rotation `A[p:] B` -> `B A[p:]` puts A[p:]
+	// and these elements of B into their final positions.
+	splitIdx := len(s) - int(tailLength)
+	if splitIdx == 0 {
+		sort.Sort(s)
+		return
+	}
+	sort.Sort(s[splitIdx:])
+
+	prefixEnd, tailEnd := splitIdx, len(s)
+	for prefixEnd > 0 && tailEnd > prefixEnd {
+		insertIdx := sort.Search(prefixEnd, func(i int) bool {
+			return s.Less(tailEnd-1, i)
+		})
+		if insertIdx == prefixEnd {
+			// the last element of B is already in its final position
+			tailEnd--
+			continue
+		}
+
+		tailLength := tailEnd - prefixEnd
+		placedCount := tailLength
+		if insertIdx > 0 {
+			placedCount = tailLength - sort.Search(tailLength, func(i int) bool {
+				return !s.Less(prefixEnd+i, insertIdx-1)
+			})
+		}
+		slices.Rotate(s[insertIdx:tailEnd], tailLength)
+		prefixEnd = insertIdx
+		tailEnd = insertIdx + tailLength - placedCount
+	}
+}
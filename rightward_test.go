@@ -0,0 +1,96 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+func testAppendedRightward(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		AppendedRightward(intSlice(s), tailLenght)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedRightward(t *testing.T) {
+	testAppendedRightward(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedRightward(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedRightward(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 14, 12, 8, 1}, 4)
+	testAppendedRightward(t, []byte{10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 1, 2, 0, 3}, 4)
+	testAppendedRightward(t, []byte{5, 4, 3, 2, 1}, 5)
+}
+
+func FuzzAppendedRightward(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedRightward(t, initial, tailLenght)
+	})
+}
+
+func BenchmarkAppendedRightward(b *testing.B) {
+	const (
+		totalSize = 65536
+		csCount   = 20
+	)
+	for _, tailKind := range []string{"random", "smaller"} {
+		for _, tailSize := range []int{16, 1024} {
+			rng := rand.New(rand.NewSource(0))
+			in := make([][]int, csCount)
+			for idx := range in {
+				in[idx] = make([]int, totalSize)
+				s := in[idx]
+				for idx := range s {
+					s[idx] = rng.Intn(totalSize)
+				}
+				if tailKind == "smaller" {
+					for idx := totalSize - tailSize; idx < totalSize; idx++ {
+						s[idx] = -s[idx] - 1
+					}
+				}
+				stdsort.Ints(s[:totalSize-tailSize])
+			}
+
+			cs := make([]intSlice, csCount)
+			for idx := range cs {
+				cs[idx] = make([]int, totalSize)
+			}
+
+			for _, f := range []struct {
+				name string
+				fn   func(intSlice, uint)
+			}{
+				{name: "Appended", fn: Appended[int, intSlice]},
+				{name: "AppendedRightward", fn: AppendedRightward[int, intSlice]},
+			} {
+				b.Run(fmt.Sprintf("tail-%s/total-%d/tail-%d/%s", tailKind, totalSize, tailSize, f.name), func(b *testing.B) {
+					b.ReportAllocs()
+					b.ResetTimer()
+					for i := 0; i < b.N; i++ {
+						idx := i % csCount
+						if idx == 0 {
+							b.StopTimer()
+							for idx := range cs {
+								copy(cs[idx], in[idx])
+							}
+							b.StartTimer()
+						}
+						f.fn(cs[idx], uint(tailSize))
+					}
+				})
+			}
+		}
+	}
+}
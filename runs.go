@@ -0,0 +1,151 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+)
+
+// mergeRunsHeapThreshold is the amount of runs above which MergeRuns uses
+// a k-way merge with a heap instead of pairwise merges.
+const mergeRunsHeapThreshold = 8
+
+// MergeRuns merges adjacent sorted runs of the slice (with lengths
+// runLengths) into one sorted slice. The sum of runLengths should be
+// equal to the length of the slice.
+//
+// The merge is stable: equal elements keep their original order.
+//
+// If the amount of runs is small, then the runs are merged pairwise,
+// otherwise a k-way merge with a heap is used.
+//
+// T: O(n*ln(k))
+//
+// S: O(n)
+func MergeRuns[E any, S Interface[E]](s S, runLengths []int) {
+	sum := 0
+	for idx, runLength := range runLengths {
+		if runLength < 0 {
+			panic(fmt.Sprintf("the length of run #%d is negative: %d", idx, runLength))
+		}
+		sum += runLength
+	}
+	if sum != len(s) {
+		panic(fmt.Sprintf("the sum of runLengths (%d) is not equal to the length of the slice (%d)", sum, len(s)))
+	}
+
+	bounds := make([]int, 1, len(runLengths)+1)
+	for _, runLength := range runLengths {
+		if runLength == 0 {
+			continue
+		}
+		bounds = append(bounds, bounds[len(bounds)-1]+runLength)
+	}
+	if len(bounds) <= 2 {
+		// not more than one run
+		return
+	}
+
+	if len(bounds)-1 > mergeRunsHeapThreshold {
+		mergeRunsHeap(s, bounds)
+	} else {
+		mergeRunsPairwise(s, bounds, nil)
+	}
+}
+
+// mergeRunsPairwise merges the runs s[bounds[i]:bounds[i+1]] pairwise
+// (like a bottom-up merge sort). If onRound is not nil, it is called after
+// each round of merges (there are `ceil(log2(runs))` rounds).
+func mergeRunsPairwise[E any, S Interface[E]](s S, bounds []int, onRound func()) {
+	buf := make([]E, len(s))
+	src, dst := s, buf
+	for len(bounds) > 2 {
+		newBounds := bounds[:1]
+		for idx := 0; idx+1 < len(bounds); idx += 2 {
+			start := bounds[idx]
+			if idx+2 >= len(bounds) {
+				// an odd run, nothing to merge with
+				end := bounds[idx+1]
+				copy(dst[start:end], src[start:end])
+				newBounds = append(newBounds, end)
+				continue
+			}
+			mid, end := bounds[idx+1], bounds[idx+2]
+			mergeRanges(dst[start:end], src, start, mid, mid, end)
+			newBounds = append(newBounds, end)
+		}
+		bounds = newBounds
+		src, dst = S(dst), src
+		if onRound != nil {
+			onRound()
+		}
+	}
+	if &src[0] != &s[0] {
+		copy(s, src)
+	}
+}
+
+// runCursor is the position of the next element of a run.
+type runCursor struct {
+	idx int
+	end int
+}
+
+// mergeRunsHeap merges the runs s[bounds[i]:bounds[i+1]] using a min-heap
+// of the heads of the runs.
+func mergeRunsHeap[E any, S Interface[E]](s S, bounds []int) {
+	cursors := make([]runCursor, len(bounds)-1)
+	for idx := range cursors {
+		cursors[idx] = runCursor{idx: bounds[idx], end: bounds[idx+1]}
+	}
+	// The runs are adjacent, so an element of an earlier run always has
+	// a lower index in s: breaking ties by the index keeps the merge stable.
+	less := func(a, b runCursor) bool {
+		if s.Less(a.idx, b.idx) {
+			return true
+		}
+		if s.Less(b.idx, a.idx) {
+			return false
+		}
+		return a.idx < b.idx
+	}
+	for idx := len(cursors)/2 - 1; idx >= 0; idx-- {
+		siftDownRunCursors(cursors, idx, less)
+	}
+
+	buf := make([]E, len(s))
+	for outIdx := range buf {
+		top := &cursors[0]
+		buf[outIdx] = s[top.idx]
+		top.idx++
+		if top.idx == top.end {
+			cursors[0] = cursors[len(cursors)-1]
+			cursors = cursors[:len(cursors)-1]
+		}
+		if len(cursors) > 0 {
+			siftDownRunCursors(cursors, 0, less)
+		}
+	}
+	copy(s, buf)
+}
+
+// siftDownRunCursors restores the min-heap property of the cursors for
+// the subtree with the root at index root.
+func siftDownRunCursors(cursors []runCursor, root int, less func(a, b runCursor) bool) {
+	for {
+		child := 2*root + 1
+		if child >= len(cursors) {
+			return
+		}
+		if child+1 < len(cursors) && less(cursors[child+1], cursors[child]) {
+			child++
+		}
+		if !less(cursors[child], cursors[root]) {
+			return
+		}
+		cursors[root], cursors[child] = cursors[child], cursors[root]
+		root = child
+	}
+}
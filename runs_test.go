@@ -0,0 +1,81 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"testing"
+)
+
+func testMergeRuns(t *testing.T, initial []byte, runLengths []int) {
+	s := make(keySeqs, len(initial))
+	for idx, v := range initial {
+		s[idx] = keySeq{Key: int(v % 16), Seq: idx}
+	}
+	// make each run sorted (stable, so Seq grows within equal keys)
+	startIdx := 0
+	for _, runLength := range runLengths {
+		run := s[startIdx : startIdx+runLength]
+		stdsort.SliceStable(run, func(i, j int) bool {
+			return run[i].Key < run[j].Key
+		})
+		startIdx += runLength
+	}
+	expected := append(keySeqs{}, s...)
+	stdsort.SliceStable(expected, func(i, j int) bool {
+		return expected[i].Key < expected[j].Key
+	})
+
+	t.Run(fmt.Sprintf("%v (runLengths: %v)", initial, runLengths), func(t *testing.T) {
+		MergeRuns(s, runLengths)
+		for idx := range expected {
+			if s[idx] != expected[idx] {
+				t.Fatalf("%v != %v", s, expected)
+			}
+		}
+	})
+}
+
+func randomRunLengths(rng *rand.Rand, length, maxRuns int) []int {
+	runs := rng.Intn(maxRuns) + 1
+	runLengths := make([]int, runs)
+	for idx := 0; idx < length; idx++ {
+		runLengths[rng.Intn(runs)]++
+	}
+	return runLengths
+}
+
+func TestMergeRuns(t *testing.T) {
+	testMergeRuns(t, nil, nil)
+	testMergeRuns(t, []byte{3, 1, 2}, []int{3})
+	testMergeRuns(t, []byte{1, 3, 5, 2, 4, 6}, []int{3, 0, 3})
+	testMergeRuns(t, []byte{1, 3, 5, 2, 4, 6, 0, 7}, []int{2, 2, 2, 2})
+	testMergeRuns(t, []byte{1, 3, 5, 2, 4, 6, 0, 7, 1, 1, 1}, []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1})
+
+	rng := rand.New(rand.NewSource(0))
+	for _, maxRuns := range []int{4, 8, 16, 64} {
+		initial := make([]byte, 1000)
+		rng.Read(initial)
+		testMergeRuns(t, initial, randomRunLengths(rng, len(initial), maxRuns))
+	}
+
+	t.Run("invalid_runLengths", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		MergeRuns(intSlice{1, 2, 3}, []int{1, 1})
+	})
+}
+
+func FuzzMergeRuns(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		rng := rand.New(rand.NewSource(int64(len(initial))))
+		testMergeRuns(t, initial, randomRunLengths(rng, len(initial), 32))
+	})
+}
@@ -13,19 +13,32 @@ import (
 )
 
 func syntaxError() {
-	fmt.Fprintf(flag.CommandLine.Output(), "syntax: benchmark_csv <benchmarks file path> <Sort/Slice CSV output> <Appended CSV output>\n")
+	fmt.Fprintf(flag.CommandLine.Output(), "syntax: benchmark_csv [-funcs <func1,func2,...>] [-baseline <benchmarks file path> -diff <diff CSV output>] [-regressions <regressions CSV output> [-reference <func>] [-tolerance <fraction>]] <benchmarks file path> <Sort/Slice CSV output> <Appended CSV output>\n")
 	flag.CommandLine.ErrorHandling()
 	os.Exit(2)
 }
 
 func main() {
+	baselinePath := flag.String("baseline", "", "path to the benchmarks file to compare with (requires -diff)")
+	diffResultsPath := flag.String("diff", "", "path to the CSV output with the comparison against the baseline (requires -baseline)")
+	regressionsPath := flag.String("regressions", "", "path to the CSV output with the Appended* results slower than the reference function")
+	reference := flag.String("reference", "sort.Sort", "the function to compare with in the regressions CSV output")
+	tolerance := flag.Float64("tolerance", 0.1, "the allowed slowdown relatively to the reference function in the regressions CSV output (0.1 is 10%)")
+	funcsStr := flag.String("funcs", "", "comma-separated list of functions to include into the CSV outputs (in the given order); all functions are included if empty")
 	flag.Parse()
 	if flag.NArg() != 3 {
 		syntaxError()
 	}
+	if (*baselinePath == "") != (*diffResultsPath == "") {
+		syntaxError()
+	}
 	benchPath := flag.Arg(0)
 	sliceResultsPath := flag.Arg(1)
 	appendedResultsPath := flag.Arg(2)
+	var funcs []string
+	if *funcsStr != "" {
+		funcs = strings.Split(*funcsStr, ",")
+	}
 
 	run, err := parseFile(benchPath)
 	if err != nil {
@@ -42,12 +55,38 @@ func main() {
 		panic(err)
 	}
 
-	err = generateCSVForSlice(sliceResultsPath, sliceBenchmarks)
+	err = generateCSVForSlice(sliceResultsPath, sliceBenchmarks, funcs)
+	if err != nil {
+		panic(err)
+	}
+
+	err = generateCSVForAppended(appendedResultsPath, appendedBenchmarks, funcs)
+	if err != nil {
+		panic(err)
+	}
+
+	if *regressionsPath != "" {
+		err = generateCSVForRegressions(*regressionsPath, appendedBenchmarks, funcs, *reference, *tolerance)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if *baselinePath == "" {
+		return
+	}
+
+	baselineRun, err := parseFile(*baselinePath)
 	if err != nil {
 		panic(err)
 	}
 
-	err = generateCSVForAppended(appendedResultsPath, appendedBenchmarks)
+	baselineBenchmarks, err := scanAppendedBenchmarks(baselineRun)
+	if err != nil {
+		panic(err)
+	}
+
+	err = generateCSVForDiff(*diffResultsPath, baselineBenchmarks, appendedBenchmarks)
 	if err != nil {
 		panic(err)
 	}
@@ -86,6 +125,12 @@ func scanSliceBenchmarks(run *benchparse.Run) (sliceBenchmarks, error) {
 		if !strings.Contains(testFullName, "Slice") && !strings.Contains(testFullName, "Sort") {
 			continue
 		}
+		if len(nameParts) > 1 && strings.HasPrefix(nameParts[1], "total-") {
+			// an Appended-like benchmark (like BenchmarkSort or
+			// BenchmarkAppendedPreSortedTail), it is not a size-only
+			// benchmark
+			continue
+		}
 
 		testName := testFullName[len(benchmarkName):]
 		if m[testName] == nil {
@@ -114,14 +159,25 @@ func scanAppendedBenchmarks(run *benchparse.Run) (appendedBenchmarks, error) {
 			return nil, fmt.Errorf("invalid result (%#v), the name is not Benchmark*", result)
 		}
 
-		if testFullName != "BenchmarkAppended" {
+		var typeName string
+		switch testFullName {
+		case "BenchmarkAppended":
+		case "BenchmarkAppendedElemTypes":
+			// "type-<typeName>/total-<totalSize>/tail-<tailSize>/<funcName>"
+			typeName = strings.TrimPrefix(nameParts[1], "type-")
+			nameParts = nameParts[1:]
+		default:
 			continue
 		}
 
 		totalSizeStr := strings.Split(nameParts[1], "-")[1]
 		tailSizeStr := strings.Split(nameParts[2], "-")[1]
 		funcNameParts := strings.Split(nameParts[3], "-")
-		caseName := fmt.Sprintf("%s-%s", strings.Join(funcNameParts[:len(funcNameParts)-1], "-"), totalSizeStr)
+		funcName := strings.Join(funcNameParts[:len(funcNameParts)-1], "-")
+		if typeName != "" {
+			funcName = fmt.Sprintf("%s(%s)", funcName, typeName)
+		}
+		caseName := fmt.Sprintf("%s-%s", funcName, totalSizeStr)
 
 		tailSize, err := strconv.ParseUint(tailSizeStr, 10, 64)
 		if err != nil {
@@ -137,16 +193,21 @@ func scanAppendedBenchmarks(run *benchparse.Run) (appendedBenchmarks, error) {
 	return m, nil
 }
 
-func generateCSVForSlice(outputPath string, m sliceBenchmarks) (err error) {
+func generateCSVForSlice(outputPath string, m sliceBenchmarks, funcs []string) (err error) {
 	var funcNames []string
 	sizesMap := map[uint64]struct{}{}
 	for funcName, m := range m {
+		if !isFuncSelected(funcs, funcName) {
+			continue
+		}
 		funcNames = append(funcNames, funcName)
 		for sliceSize := range m {
 			sizesMap[sliceSize] = struct{}{}
 		}
 	}
-	sort.Strings(funcNames)
+	sortFuncNames(funcNames, funcs, func(funcName string) string {
+		return funcName
+	})
 
 	var sizes []uint64
 	for size := range sizesMap {
@@ -208,19 +269,22 @@ func generateCSVForSlice(outputPath string, m sliceBenchmarks) (err error) {
 	return w.Error()
 }
 
-func generateCSVForAppended(outputPath string, m appendedBenchmarks) error {
+func generateCSVForAppended(outputPath string, m appendedBenchmarks, funcs []string) error {
 	var caseNames []string
 	tailSizeMap := map[uint64]struct{}{}
 	for caseName, m := range m {
 		if !strings.HasSuffix(caseName, "-1048576") {
 			continue
 		}
+		if !isFuncSelected(funcs, caseFuncName(caseName)) {
+			continue
+		}
 		caseNames = append(caseNames, caseName)
 		for tailSize := range m {
 			tailSizeMap[tailSize] = struct{}{}
 		}
 	}
-	sort.Strings(caseNames)
+	sortFuncNames(caseNames, funcs, caseFuncName)
 
 	var tailSizes []uint64
 	for tailSize := range tailSizeMap {
@@ -281,3 +345,48 @@ func generateCSVForAppended(outputPath string, m appendedBenchmarks) error {
 	w.Flush()
 	return w.Error()
 }
+
+// caseFuncName returns the function name of an Appended case
+// name (which is "<funcName>-<totalSize>" or
+// "<funcName>(<typeName>)-<totalSize>").
+func caseFuncName(caseName string) string {
+	funcName := caseName[:strings.LastIndex(caseName, "-")]
+	if idx := strings.Index(funcName, "("); idx >= 0 {
+		funcName = funcName[:idx]
+	}
+	return funcName
+}
+
+// isFuncSelected returns true if the function should be included into
+// the CSV output according to the "-funcs" flag.
+func isFuncSelected(funcs []string, funcName string) bool {
+	if len(funcs) == 0 {
+		return true
+	}
+	for _, selected := range funcs {
+		if selected == funcName {
+			return true
+		}
+	}
+	return false
+}
+
+// sortFuncNames sorts the names in the order of the functions in the "-funcs"
+// flag (or alphabetically if the flag is not set).
+func sortFuncNames(names []string, funcs []string, funcNameOf func(name string) string) {
+	if len(funcs) == 0 {
+		sort.Strings(names)
+		return
+	}
+	order := map[string]int{}
+	for idx, funcName := range funcs {
+		order[funcName] = idx
+	}
+	sort.Slice(names, func(i, j int) bool {
+		orderI, orderJ := order[funcNameOf(names[i])], order[funcNameOf(names[j])]
+		if orderI != orderJ {
+			return orderI < orderJ
+		}
+		return names[i] < names[j]
+	})
+}
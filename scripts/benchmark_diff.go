@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cep21/benchparse"
+)
+
+// generateCSVForDiff writes a CSV comparing the latencies of the same
+// benchmarks (funcName, totalSize, tailSize) in two runs. Benchmarks
+// present only in one of the runs are skipped.
+func generateCSVForDiff(outputPath string, oldM, newM appendedBenchmarks) error {
+	var caseNames []string
+	for caseName := range newM {
+		if _, ok := oldM[caseName]; !ok {
+			continue
+		}
+		caseNames = append(caseNames, caseName)
+	}
+	sort.Strings(caseNames)
+
+	f, err := os.OpenFile(outputPath, os.O_WRONLY|os.O_EXCL|os.O_CREATE, 0640)
+	if err != nil {
+		return fmt.Errorf("unable to create file '%s': %w", outputPath, err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+
+	if err := w.Write([]string{"funcName", "totalSize", "tailSize", "old ns/op", "new ns/op", "change %"}); err != nil {
+		return fmt.Errorf("unable to write CSV: %w", err)
+	}
+
+	for _, caseName := range caseNames {
+		var tailSizes []uint64
+		for tailSize := range newM[caseName] {
+			if _, ok := oldM[caseName][tailSize]; !ok {
+				continue
+			}
+			tailSizes = append(tailSizes, tailSize)
+		}
+		sort.Slice(tailSizes, func(i, j int) bool {
+			return tailSizes[i] < tailSizes[j]
+		})
+
+		sepIdx := strings.LastIndex(caseName, "-")
+		funcName, totalSize := caseName[:sepIdx], caseName[sepIdx+1:]
+		for _, tailSize := range tailSizes {
+			oldLatency, oldOK := averageRuntime(oldM[caseName][tailSize])
+			newLatency, newOK := averageRuntime(newM[caseName][tailSize])
+			if !oldOK || !newOK {
+				// no ns/op values to compare
+				continue
+			}
+			outLine := []string{
+				funcName,
+				totalSize,
+				fmt.Sprintf("%d", tailSize),
+				strconv.FormatFloat(oldLatency, 'f', 2, 64),
+				strconv.FormatFloat(newLatency, 'f', 2, 64),
+				strconv.FormatFloat((newLatency-oldLatency)/oldLatency*100, 'f', 2, 64),
+			}
+			if err := w.Write(outLine); err != nil {
+				return fmt.Errorf("unable to write CSV: %w", err)
+			}
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// averageRuntime returns the average ns/op among the results. ok is false
+// if there are no ns/op values among the results (or their average is
+// zero, so no relative change can be computed against it).
+func averageRuntime(results []*benchparse.BenchmarkResult) (avg float64, ok bool) {
+	var (
+		sum   float64
+		count int
+	)
+	for _, result := range results {
+		for _, value := range result.Values {
+			if value.Unit == benchparse.UnitRuntime {
+				sum += value.Value
+				count++
+			}
+		}
+	}
+	if count == 0 || sum == 0 {
+		return 0, false
+	}
+	return sum / float64(count), true
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// generateCSVForRegressions writes a CSV with a row per (funcName,
+// totalSize, tailSize) where an Appended* function is slower than
+// the reference function (like "sort.Sort") of the same benchmark by
+// more than the given tolerance (a fraction, like 0.1 for 10%).
+//
+// When the appended optimization is not applicable, Appended falls back
+// to a full sort, so it should never be noticeably slower than
+// the reference: each row is a suspicious result. Cases without
+// the reference function are skipped.
+func generateCSVForRegressions(outputPath string, m appendedBenchmarks, funcs []string, reference string, tolerance float64) error {
+	var caseNames []string
+	for caseName := range m {
+		funcName := caseFuncName(caseName)
+		if !strings.HasPrefix(funcName, "Appended") || !isFuncSelected(funcs, funcName) {
+			continue
+		}
+		caseNames = append(caseNames, caseName)
+	}
+	sort.Strings(caseNames)
+
+	f, err := os.OpenFile(outputPath, os.O_WRONLY|os.O_EXCL|os.O_CREATE, 0640)
+	if err != nil {
+		return fmt.Errorf("unable to create file '%s': %w", outputPath, err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+
+	if err := w.Write([]string{"funcName", "totalSize", "tailSize", "reference ns/op", "ns/op", "slowdown %"}); err != nil {
+		return fmt.Errorf("unable to write CSV: %w", err)
+	}
+
+	for _, caseName := range caseNames {
+		referenceResults := m[referenceCaseName(caseName, reference)]
+		if referenceResults == nil {
+			continue
+		}
+
+		var tailSizes []uint64
+		for tailSize := range m[caseName] {
+			if _, ok := referenceResults[tailSize]; !ok {
+				continue
+			}
+			tailSizes = append(tailSizes, tailSize)
+		}
+		sort.Slice(tailSizes, func(i, j int) bool {
+			return tailSizes[i] < tailSizes[j]
+		})
+
+		sepIdx := strings.LastIndex(caseName, "-")
+		funcName, totalSize := caseName[:sepIdx], caseName[sepIdx+1:]
+		for _, tailSize := range tailSizes {
+			referenceLatency, referenceOK := averageRuntime(referenceResults[tailSize])
+			latency, ok := averageRuntime(m[caseName][tailSize])
+			if !referenceOK || !ok {
+				// no ns/op values to compare
+				continue
+			}
+			if latency <= referenceLatency*(1+tolerance) {
+				continue
+			}
+			outLine := []string{
+				funcName,
+				totalSize,
+				fmt.Sprintf("%d", tailSize),
+				strconv.FormatFloat(referenceLatency, 'f', 2, 64),
+				strconv.FormatFloat(latency, 'f', 2, 64),
+				strconv.FormatFloat((latency-referenceLatency)/referenceLatency*100, 'f', 2, 64),
+			}
+			if err := w.Write(outLine); err != nil {
+				return fmt.Errorf("unable to write CSV: %w", err)
+			}
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// referenceCaseName returns the name of the case of the reference
+// function in the same benchmark (with the same element type and
+// the same totalSize) as the given case.
+func referenceCaseName(caseName, reference string) string {
+	sepIdx := strings.LastIndex(caseName, "-")
+	funcName, totalSizeSuffix := caseName[:sepIdx], caseName[sepIdx:]
+	var typeSuffix string
+	if idx := strings.Index(funcName, "("); idx >= 0 {
+		typeSuffix = funcName[idx:]
+	}
+	return reference + typeSuffix + totalSizeSuffix
+}
@@ -0,0 +1,38 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"cmp"
+
+	"github.com/go-ng/sort"
+)
+
+// SearchElem uses binary search to find the first index `i` in the sorted
+// slice, for which lessThanTarget(i) is false (or len(s) if there is
+// no such index).
+//
+// lessThanTarget(i) should report if s[i] is less than the searched
+// element (thus it is true for a prefix of the sorted slice and false
+// for the rest of it).
+//
+// T: O(ln(n))
+func SearchElem[E any, S Interface[E]](s S, lessThanTarget func(i int) bool) int {
+	return sort.Search(len(s), func(i int) bool {
+		return !lessThanTarget(i)
+	})
+}
+
+// SearchOrdered uses binary search to find target in the sorted slice.
+// It returns the index of the first element which is not less than target
+// and reports whether the target is found at this index.
+//
+// T: O(ln(n))
+func SearchOrdered[E cmp.Ordered](s []E, target E) (int, bool) {
+	idx := sort.Search(len(s), func(i int) bool {
+		return !cmp.Less(s[i], target)
+	})
+	return idx, idx < len(s) && cmp.Compare(s[idx], target) == 0
+}
@@ -0,0 +1,66 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSearchElem(t *testing.T) {
+	s := intSlice{1, 3, 3, 3, 7, 9}
+	for _, testCase := range []struct {
+		target   int
+		expected int
+	}{
+		{target: 0, expected: 0},
+		{target: 1, expected: 0},
+		{target: 2, expected: 1},
+		{target: 3, expected: 1},
+		{target: 8, expected: 5},
+		{target: 10, expected: 6},
+	} {
+		idx := SearchElem(s, func(i int) bool {
+			return s[i] < testCase.target
+		})
+		if idx != testCase.expected {
+			t.Errorf("target %d: %d != %d", testCase.target, idx, testCase.expected)
+		}
+	}
+
+	if idx := SearchElem(intSlice{}, func(i int) bool { return true }); idx != 0 {
+		t.Errorf("empty slice: %d != 0", idx)
+	}
+}
+
+func TestSearchOrdered(t *testing.T) {
+	s := []int{1, 3, 3, 3, 7, 9}
+	for _, testCase := range []struct {
+		target   int
+		expected int
+		found    bool
+	}{
+		{target: 0, expected: 0, found: false},
+		{target: 1, expected: 0, found: true},
+		{target: 2, expected: 1, found: false},
+		{target: 3, expected: 1, found: true},
+		{target: 9, expected: 5, found: true},
+		{target: 10, expected: 6, found: false},
+	} {
+		idx, found := SearchOrdered(s, testCase.target)
+		if idx != testCase.expected || found != testCase.found {
+			t.Errorf("target %d: (%d, %t) != (%d, %t)", testCase.target, idx, found, testCase.expected, testCase.found)
+		}
+	}
+
+	if idx, found := SearchOrdered([]int(nil), 1); idx != 0 || found {
+		t.Errorf("empty slice: (%d, %t) != (0, false)", idx, found)
+	}
+
+	idx, found := SearchOrdered([]float64{math.NaN(), 1, 2}, math.NaN())
+	if idx != 0 || !found {
+		t.Errorf("NaN: (%d, %t) != (0, true)", idx, found)
+	}
+}
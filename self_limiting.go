@@ -0,0 +1,43 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"github.com/go-ng/sort"
+)
+
+// selfLimitingWorkFactor is the multiplier of `n*log2(n)` defining
+// how much work (comparisons of the binary searches plus element moves)
+// AppendedSelfLimiting allows before it gives up on the group-insert
+// strategy.
+const selfLimitingWorkFactor = 1
+
+// AppendedSelfLimiting is the same as Appended, but it also counts
+// the work performed by the group-insert (the comparisons of the binary
+// searches plus the moved elements). Once the work exceeds an estimation
+// of a full sort (`n*log2(n)`), it stops and sorts by `sort.Sort` only
+// the part which is not finished yet.
+//
+// Unlike AppendedAdaptiveAbort, it still relies on the upfront decision
+// of Appended (whether to use the optimization at all); the counter
+// only makes the worst case robust if this decision turns out to be wrong.
+//
+// T: O(min(k*ln(n) + n + k^2, n*ln(n)))
+//
+// S: O(1) [if without `s`]
+func AppendedSelfLimiting[E any, S Interface[E]](s S, tailLength uint) {
+	strategy := startAppended(s, tailLength, appendedPolicy{})
+	if strategy != StrategyGroupInsert {
+		finishAppended(s, tailLength, strategy)
+		return
+	}
+
+	splitIdx := uint(len(s)) - tailLength
+	sortTailDescending(s[splitIdx:])
+	budget := nLogNBudget(len(s), selfLimitingWorkFactor)
+	if unfinishedEnd := groupInsertDescendingTailLimited(s, splitIdx, budget, true, nil); unfinishedEnd > 0 {
+		sort.Sort(s[:unfinishedEnd])
+	}
+}
@@ -0,0 +1,133 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+func testAppendedSelfLimiting(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		AppendedSelfLimiting(intSlice(s), tailLenght)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+// interleavedTail returns a sorted slice of even numbers with a tail
+// of odd numbers spread across the whole prefix: each tail element
+// is inserted in a different place.
+func interleavedTail(totalSize, tailSize int) intSlice {
+	s := make(intSlice, 0, totalSize)
+	for v := 0; v < totalSize-tailSize; v++ {
+		s = append(s, v*2)
+	}
+	step := (totalSize - tailSize) / tailSize
+	for idx := 0; idx < tailSize; idx++ {
+		s = append(s, idx*step*2+1)
+	}
+	return s
+}
+
+func TestAppendedSelfLimiting(t *testing.T) {
+	testAppendedSelfLimiting(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedSelfLimiting(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedSelfLimiting(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 14, 12, 8, 1}, 4)
+	testAppendedSelfLimiting(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 16, 18, 20, 21}, 4)
+	testAppendedSelfLimiting(t, []byte{5, 4, 3, 2, 1}, 5)
+
+	t.Run("pathological", func(t *testing.T) {
+		// the upfront estimation allows the optimization for this tail
+		// length, but each tail element is inserted far from the others
+		const totalSize, tailSize = 65536, 2000
+		if !shouldUseAppended(totalSize, tailSize) {
+			t.Fatal("the test case is expected to pass the upfront estimation")
+		}
+
+		s := interleavedTail(totalSize, tailSize)
+		sortTailDescending(s[totalSize-tailSize:])
+		unfinishedEnd := groupInsertDescendingTailLimited(s, totalSize-tailSize, nLogNBudget(totalSize, selfLimitingWorkFactor), true, nil)
+		if unfinishedEnd == 0 {
+			t.Fatal("expected the budget to be exceeded")
+		}
+
+		s = interleavedTail(totalSize, tailSize)
+		AppendedSelfLimiting(s, tailSize)
+		if !stdsort.IntsAreSorted(s) {
+			t.Fatal("not sorted")
+		}
+	})
+}
+
+func FuzzAppendedSelfLimiting(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedSelfLimiting(t, initial, tailLenght)
+
+		// an arbitrary budget: the unfinished part should be enough to be resorted
+		s, _, _, _ := prepareTestCase(initial, tailLenght)
+		c := make([]int, len(s))
+		copy(c, s)
+		splitIdx := uint(len(s)) - tailLenght
+		if splitIdx == 0 {
+			return
+		}
+		sortTailDescending(intSlice(s[splitIdx:]))
+		unfinishedEnd := groupInsertDescendingTailLimited(intSlice(s), splitIdx, rand.Intn(len(s)*2), rand.Intn(2) == 0, nil)
+		stdsort.Ints(s[:unfinishedEnd])
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v (unfinishedEnd: %d)", c, s, unfinishedEnd)
+		}
+	})
+}
+
+func BenchmarkAppendedSelfLimiting(b *testing.B) {
+	const (
+		totalSize = 65536
+		csCount   = 20
+	)
+	for _, tailSize := range []int{16, 2000} {
+		in := interleavedTail(totalSize, tailSize)
+
+		cs := make([]intSlice, csCount)
+		for idx := range cs {
+			cs[idx] = make([]int, totalSize)
+		}
+
+		for _, f := range []struct {
+			name string
+			fn   func(intSlice, uint)
+		}{
+			{name: "Appended", fn: Appended[int, intSlice]},
+			{name: "AppendedSelfLimiting", fn: AppendedSelfLimiting[int, intSlice]},
+		} {
+			b.Run(fmt.Sprintf("total-%d/tail-%d/interleaved/%s", totalSize, tailSize, f.name), func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range cs {
+							copy(cs[idx], in)
+						}
+						b.StartTimer()
+					}
+					f.fn(cs[idx], uint(tailSize))
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	stdsort "sort"
+
+	"github.com/go-ng/slices"
+	"github.com/go-ng/sort"
+)
+
+// sequence is the access to the elements used by appendedSeq, which is
+// the single implementation of the in-place Appended algorithm. Appended,
+// AppendedFunc, AppendedIndexed, AppendedKV (and the others) only differ
+// in the sequence they pass.
+//
+// sequence is used only as a type constraint (not as an interface value),
+// so the calls are not more expensive than calls of Less of an Interface.
+type sequence interface {
+	Len() int
+	Less(i, j int) bool
+	Swap(i, j int)
+
+	// Rotate is the same as `slices.Rotate(s[a:b], shift)`.
+	Rotate(a, b, shift int)
+
+	// Reverse is the same as `slices.Reverse(s[a:b])`.
+	Reverse(a, b int)
+
+	// Sort sorts the range [a, b) in ascending order.
+	Sort(a, b int)
+
+	// SortDescending sorts the range [a, b) in descending order.
+	SortDescending(a, b int)
+}
+
+// stdInterface adapts an Interface to the standard `sort.Interface` and
+// to sequence.
+type stdInterface[E any, S Interface[E]] []E
+
+func (s stdInterface[E, S]) Len() int {
+	return len(s)
+}
+
+func (s stdInterface[E, S]) Less(i, j int) bool {
+	return S(s).Less(i, j)
+}
+
+func (s stdInterface[E, S]) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+func (s stdInterface[E, S]) Rotate(a, b, shift int) {
+	slices.Rotate(s[a:b], shift)
+}
+
+func (s stdInterface[E, S]) Reverse(a, b int) {
+	slices.Reverse(s[a:b])
+}
+
+func (s stdInterface[E, S]) Sort(a, b int) {
+	sort.Sort(S(s[a:b]))
+}
+
+func (s stdInterface[E, S]) SortDescending(a, b int) {
+	sub := S(s[a:b])
+	sort.Slice(sub, func(i, j int) bool {
+		return sub.Less(j, i)
+	})
+}
+
+// funcSeq is a sequence of a plain slice and a less function.
+type funcSeq[E any] struct {
+	s    []E
+	less func(a, b E) bool
+}
+
+func (q funcSeq[E]) Len() int {
+	return len(q.s)
+}
+
+func (q funcSeq[E]) Less(i, j int) bool {
+	return q.less(q.s[i], q.s[j])
+}
+
+func (q funcSeq[E]) Swap(i, j int) {
+	q.s[i], q.s[j] = q.s[j], q.s[i]
+}
+
+func (q funcSeq[E]) Rotate(a, b, shift int) {
+	slices.Rotate(q.s[a:b], shift)
+}
+
+func (q funcSeq[E]) Reverse(a, b int) {
+	slices.Reverse(q.s[a:b])
+}
+
+func (q funcSeq[E]) Sort(a, b int) {
+	sub := q.s[a:b]
+	sort.Slice(sub, func(i, j int) bool {
+		return q.less(sub[i], sub[j])
+	})
+}
+
+func (q funcSeq[E]) SortDescending(a, b int) {
+	sub := q.s[a:b]
+	sort.Slice(sub, func(i, j int) bool {
+		return q.less(sub[j], sub[i])
+	})
+}
+
+// indexedSeq is a sequence of a standard `sort.Interface`: all the moves
+// of elements are performed through Swap.
+type indexedSeq struct {
+	stdsort.Interface
+}
+
+func (q indexedSeq) Rotate(a, b, shift int) {
+	rotateIndexed(q.Interface, a, b, shift)
+}
+
+func (q indexedSeq) Reverse(a, b int) {
+	reverseIndexed(q.Interface, a, b)
+}
+
+func (q indexedSeq) Sort(a, b int) {
+	stdsort.Sort(subInterface{
+		Interface: q.Interface,
+		offset:    a,
+		length:    b - a,
+	})
+}
+
+func (q indexedSeq) SortDescending(a, b int) {
+	stdsort.Sort(stdsort.Reverse(subInterface{
+		Interface: q.Interface,
+		offset:    a,
+		length:    b - a,
+	}))
+}
@@ -0,0 +1,24 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"math/rand"
+)
+
+// Shuffle randomly permutes the slice using the Fisher–Yates algorithm
+// with the provided random source. The same seed of rng gives the same
+// permutation, which makes it useful to prepare reproducible inputs
+// for tests and benchmarks.
+//
+// T: O(n)
+//
+// S: O(1)
+func Shuffle[E any, S Interface[E]](s S, rng *rand.Rand) {
+	for idx := len(s) - 1; idx > 0; idx-- {
+		swapIdx := rng.Intn(idx + 1)
+		s[idx], s[swapIdx] = s[swapIdx], s[idx]
+	}
+}
@@ -0,0 +1,93 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"testing"
+)
+
+func testShuffle(t *testing.T, initial []byte, seed int64) {
+	s := make([]int, len(initial))
+	for idx, v := range initial {
+		s[idx] = int(v)
+	}
+	stdsort.Ints(s)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(fmt.Sprintf("%v/seed-%d", initial, seed), func(t *testing.T) {
+		Shuffle(intSlice(s), rand.New(rand.NewSource(seed)))
+		again := make([]int, len(c))
+		copy(again, c)
+		Shuffle(intSlice(again), rand.New(rand.NewSource(seed)))
+		if !intsEqual(s, again) {
+			t.Fatalf("the permutation is not deterministic: %v != %v", s, again)
+		}
+
+		Sort(intSlice(s))
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v", c, s)
+		}
+	})
+}
+
+func TestShuffle(t *testing.T) {
+	testShuffle(t, []byte{}, 0)
+	testShuffle(t, []byte{1}, 0)
+	testShuffle(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 0)
+	testShuffle(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 1)
+
+	t.Run("permutes", func(t *testing.T) {
+		s := make(intSlice, 100)
+		for idx := range s {
+			s[idx] = idx
+		}
+		Shuffle(s, rand.New(rand.NewSource(0)))
+		if stdsort.IntsAreSorted(s) {
+			t.Fatalf("the slice is not shuffled: %v", s)
+		}
+	})
+
+	t.Run("uniform", func(t *testing.T) {
+		// all 6 permutations of 3 elements should appear roughly equally
+		counts := map[[3]int]int{}
+		rng := rand.New(rand.NewSource(0))
+		const runs = 60000
+		for run := 0; run < runs; run++ {
+			s := intSlice{0, 1, 2}
+			Shuffle(s, rng)
+			counts[[3]int{s[0], s[1], s[2]}]++
+		}
+		if len(counts) != 6 {
+			t.Fatalf("expected 6 permutations, got %v", counts)
+		}
+		for perm, count := range counts {
+			if count < runs/6*9/10 || count > runs/6*11/10 {
+				t.Fatalf("permutation %v is not uniform: %v", perm, counts)
+			}
+		}
+	})
+}
+
+func FuzzShuffle(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial []byte, seed int64) {
+		testShuffle(t, initial, seed)
+	})
+}
+
+func BenchmarkShuffle(b *testing.B) {
+	s := make(intSlice, 65536)
+	for idx := range s {
+		s[idx] = idx
+	}
+	rng := rand.New(rand.NewSource(0))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Shuffle(s, rng)
+	}
+}
@@ -0,0 +1,78 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"math"
+	stdsort "sort"
+)
+
+// AppendedSizeClass defines up to which tail length Appended uses
+// the optimization for slices of a range of lengths, see
+// AppendedSizeClasses.
+type AppendedSizeClass struct {
+	// MaxTotalSize is the (exclusive) upper bound of the lengths
+	// of the slices of this class.
+	MaxTotalSize uint
+
+	// MaxTailAbsolute (if not zero) is the (exclusive) upper bound
+	// of the tail length.
+	MaxTailAbsolute uint
+
+	// MaxTailSquareFactor (if not zero) is the (exclusive) upper bound
+	// of the square of the tail length relative to the length of the slice:
+	// the optimization is used if `k^2 < MaxTailSquareFactor*n` (which is
+	// where the "k^2" term of Appended starts to dominate). It is used
+	// only if MaxTailAbsolute is zero.
+	MaxTailSquareFactor uint
+
+	// MaxTailFraction is the (exclusive) upper bound of the tail length
+	// relative to the length of the slice. It is used only if
+	// both MaxTailAbsolute and MaxTailSquareFactor are zero.
+	MaxTailFraction float64
+}
+
+// AppendedSizeClasses is the table used by Appended to decide whether
+// to use the optimization (or just to sort the whole slice), sorted
+// by MaxTotalSize. The first class with MaxTotalSize greater than
+// the length of the slice is used; if there is no such class, then
+// the optimization is not used.
+//
+// The upper bound of the tail length should not decrease with
+// the length of the slice.
+//
+// It is not safe to modify AppendedSizeClasses concurrently with calls of
+// Appended; set it once on initialization.
+var AppendedSizeClasses = defaultAppendedSizeClasses()
+
+// defaultAppendedSizeClasses returns the default AppendedSizeClasses:
+// for small slices `k < n/4` (where `k^2` is not dominating yet),
+// otherwise `k^2 < 64*n`.
+func defaultAppendedSizeClasses() []AppendedSizeClass {
+	return []AppendedSizeClass{
+		{MaxTotalSize: 512, MaxTailFraction: 0.25},
+		{MaxTotalSize: math.MaxUint, MaxTailSquareFactor: 64},
+	}
+}
+
+// shouldUseAppendedTable returns true if Appended should use
+// the optimization according to AppendedSizeClasses.
+func shouldUseAppendedTable(totalSize, tailSize uint) bool {
+	classes := AppendedSizeClasses
+	idx := stdsort.Search(len(classes), func(i int) bool {
+		return totalSize < classes[i].MaxTotalSize
+	})
+	if idx == len(classes) {
+		return false
+	}
+	class := classes[idx]
+	switch {
+	case class.MaxTailAbsolute != 0:
+		return tailSize < class.MaxTailAbsolute
+	case class.MaxTailSquareFactor != 0:
+		return mulLess(tailSize, tailSize, totalSize, class.MaxTailSquareFactor)
+	}
+	return float64(tailSize) < float64(totalSize)*class.MaxTailFraction
+}
@@ -0,0 +1,129 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// shouldUseAppendedSwitch is the decision used before AppendedSizeClasses
+// were introduced.
+func shouldUseAppendedSwitch(totalSize, tailSize uint) bool {
+	switch {
+	case totalSize < 512:
+		return mulLess(tailSize, 4, totalSize, 1)
+	default:
+		return mulLess(tailSize, tailSize, totalSize, 64)
+	}
+}
+
+func TestAppendedSizeClasses(t *testing.T) {
+	t.Run("sample_points", func(t *testing.T) {
+		for _, testCase := range []struct {
+			totalSize, maxTail uint
+		}{
+			{32, 7},
+			{64, 15},
+			{128, 31},
+			{256, 63},
+			{512, 181},
+			{1024, 255},
+			{32768, 1448},
+			{65536, 2047},
+			{131072, 2896},
+			{262144, 4095},
+			{524288, 5792},
+			{1048576, 8191},
+		} {
+			for _, tailSize := range []uint{0, 1, testCase.maxTail - 1, testCase.maxTail, testCase.maxTail + 1, testCase.totalSize} {
+				expected := tailSize <= testCase.maxTail
+				if shouldUseAppendedSwitch(testCase.totalSize, tailSize) != expected {
+					t.Fatalf("invalid test case: %d/%d", testCase.totalSize, tailSize)
+				}
+				if r := shouldUseAppendedTable(testCase.totalSize, tailSize); r != expected {
+					t.Fatalf("%d/%d: %t != %t", testCase.totalSize, tailSize, r, expected)
+				}
+			}
+		}
+	})
+
+	t.Run("exact", func(t *testing.T) {
+		check := func(totalSize, tailSize uint) {
+			if shouldUseAppendedTable(totalSize, tailSize) != shouldUseAppendedSwitch(totalSize, tailSize) {
+				t.Fatalf("%d/%d: %t", totalSize, tailSize, shouldUseAppendedTable(totalSize, tailSize))
+			}
+		}
+
+		// all the tail lengths for small slices
+		for totalSize := uint(0); totalSize < 4096; totalSize++ {
+			for tailSize := uint(0); tailSize <= totalSize; tailSize++ {
+				check(totalSize, tailSize)
+			}
+		}
+
+		// the tail lengths around the boundary for each length up to 4M,
+		// and for random lengths up to MaxInt/2
+		checkBoundary := func(totalSize uint) {
+			boundary := uint(math.Sqrt(64 * float64(totalSize)))
+			for tailSize := boundary - 2; tailSize <= boundary+2; tailSize++ {
+				check(totalSize, tailSize)
+			}
+		}
+		for totalSize := uint(4096); totalSize < 4<<20; totalSize++ {
+			checkBoundary(totalSize)
+		}
+		rng := rand.New(rand.NewSource(0))
+		for i := 0; i < 100000; i++ {
+			totalSize := 4096 + uint(rng.Int63n(math.MaxInt>>1))
+			checkBoundary(totalSize)
+			check(totalSize, uint(rng.Int63n(int64(totalSize))))
+		}
+	})
+
+	t.Run("sorted", func(t *testing.T) {
+		for idx := 1; idx < len(AppendedSizeClasses); idx++ {
+			prev, cur := AppendedSizeClasses[idx-1], AppendedSizeClasses[idx]
+			if cur.MaxTotalSize <= prev.MaxTotalSize || (prev.MaxTailAbsolute != 0 && cur.MaxTailAbsolute != 0 && cur.MaxTailAbsolute < prev.MaxTailAbsolute) {
+				t.Fatalf("%v is not after %v", cur, prev)
+			}
+		}
+	})
+
+	t.Run("replaced", func(t *testing.T) {
+		defer func(classes []AppendedSizeClass) {
+			AppendedSizeClasses = classes
+		}(AppendedSizeClasses)
+		AppendedSizeClasses = []AppendedSizeClass{{MaxTotalSize: 1000, MaxTailAbsolute: 10}}
+		for _, testCase := range []struct {
+			totalSize, tailSize uint
+			expected            bool
+		}{
+			{100, 9, true},
+			{100, 10, false},
+			{999, 9, true},
+			{1000, 1, false},
+		} {
+			if r := shouldUseAppended(testCase.totalSize, testCase.tailSize); r != testCase.expected {
+				t.Fatalf("%v: %t", testCase, r)
+			}
+		}
+		if r := CrossoverTailLength(500); r != 9 {
+			t.Fatalf("unexpected crossover: %d", r)
+		}
+	})
+}
+
+func BenchmarkShouldUseAppended(b *testing.B) {
+	for _, totalSize := range []uint{100, 65536} {
+		b.Run(fmt.Sprintf("total-%d", totalSize), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				shouldUseAppended(totalSize, uint(i)%totalSize)
+			}
+		})
+	}
+}
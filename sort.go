@@ -0,0 +1,44 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"math"
+
+	"github.com/go-ng/sort"
+)
+
+// Sort is a drop-in replacement for `sort.Sort`, which detects if the slice
+// is an already sorted slice with few unsorted elements appended to the end.
+// In this case it uses Appended, otherwise it just calls `sort.Sort`.
+//
+// The detection consists of a backward scan of at most `sqrt(n)` last
+// elements (to find where the unsorted tail begins, see EstimateTailLength)
+// and a forward scan of the prefix, which stops on the first unsorted pair.
+// Thus on random input the overhead is only O(sqrt(n)) comparisons.
+//
+// T: O(n*ln(n)), or the same as Appended if the unsorted tail is
+// not longer than `sqrt(n)`.
+//
+// S: O(ln(n))
+func Sort[E any, S Interface[E]](s S) {
+	length := len(s)
+	if length < 2 {
+		return
+	}
+
+	tailLength, confident := EstimateTailLength(s, int(math.Sqrt(float64(length))))
+	if !confident {
+		// the unsorted part may be longer than sqrt(n)
+		sort.Sort(s)
+		return
+	}
+	if !IsAppendedSortable(s, tailLength) {
+		sort.Sort(s)
+		return
+	}
+
+	Appended(s, tailLength)
+}
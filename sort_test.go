@@ -0,0 +1,95 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+
+	"github.com/go-ng/sort"
+)
+
+func testSort(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		Sort(intSlice(s))
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestSort(t *testing.T) {
+	testSort(t, nil, 0)
+	testSort(t, []byte{1}, 1)
+	testSort(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testSort(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 0)
+	testSort(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testSort(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 16)
+	testSort(t, []byte{49, 255, 127}, 2)
+}
+
+func FuzzSort(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testSort(t, initial, tailLenght)
+	})
+}
+
+func BenchmarkSort(b *testing.B) {
+	const (
+		totalSize = 65536
+		csCount   = 20
+	)
+	for _, tailSize := range []int{16, 128, totalSize} {
+		b.Run(fmt.Sprintf("total-%d/tail-%d", totalSize, tailSize), func(b *testing.B) {
+			rng := rand.New(rand.NewSource(0))
+			in := make([][]int, csCount)
+			for idx := range in {
+				in[idx] = make([]int, totalSize)
+				s := in[idx]
+				for idx := range s {
+					s[idx] = rng.Intn(totalSize)
+				}
+				stdsort.Ints(s[:len(s)-tailSize])
+			}
+
+			cs := make([]intSlice, csCount)
+			for idx := range cs {
+				cs[idx] = make([]int, totalSize)
+			}
+
+			for _, bc := range []struct {
+				name string
+				fn   func(s intSlice)
+			}{
+				{"sort.Sort", func(s intSlice) { sort.Sort(s) }},
+				{"Sort", func(s intSlice) { Sort(s) }},
+			} {
+				b.Run(bc.name, func(b *testing.B) {
+					b.ReportAllocs()
+					b.ResetTimer()
+					for i := 0; i < b.N; i++ {
+						idx := i % csCount
+						if idx == 0 {
+							b.StopTimer()
+							for idx := range cs {
+								copy(cs[idx], in[idx])
+							}
+							b.StartTimer()
+						}
+						bc.fn(cs[idx])
+					}
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+
+	"github.com/go-ng/sort"
+)
+
+// Sorted is a slice, which is kept sorted across modifications.
+//
+// The zero value is an empty sorted slice ready to use.
+type Sorted[E any, S Interface[E]] struct {
+	s S
+}
+
+// NewSorted returns a Sorted using s as the initial content (s is sorted
+// in-place, and it is owned by the returned Sorted).
+func NewSorted[E any, S Interface[E]](s S) *Sorted[E, S] {
+	sort.Sort(s)
+	return &Sorted[E, S]{s: s}
+}
+
+// Insert inserts v keeping the slice sorted.
+//
+// T: O(ln(n) + n)
+func (s *Sorted[E, S]) Insert(v E) {
+	s.s = SortedInsert(s.s, v)
+}
+
+// BulkInsert inserts all the vs keeping the slice sorted. It is much
+// faster than calling Insert for each element (see Appended).
+//
+// T: O(k*ln(n) + n + k^2) -- thus if `k` is too high then: O(k^2)
+func (s *Sorted[E, S]) BulkInsert(vs []E) {
+	s.s = SortedInsertMany(s.s, vs)
+}
+
+// Remove removes the i-th element.
+//
+// T: O(n)
+func (s *Sorted[E, S]) Remove(i int) {
+	if i < 0 || i >= len(s.s) {
+		panic(fmt.Sprintf("index %d is out of range [0, %d)", i, len(s.s)))
+	}
+	copy(s.s[i:], s.s[i+1:])
+	var zero E
+	s.s[len(s.s)-1] = zero
+	s.s = s.s[:len(s.s)-1]
+}
+
+// Len returns the amount of elements.
+func (s *Sorted[E, S]) Len() int {
+	return len(s.s)
+}
+
+// Slice returns the sorted slice. The slice shares the memory with
+// the Sorted: it should not be modified, and it may be invalidated by
+// the next modification of the Sorted.
+func (s *Sorted[E, S]) Slice() S {
+	return s.s
+}
@@ -0,0 +1,66 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"math/rand"
+	stdsort "sort"
+	"testing"
+)
+
+func TestSorted(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	s := NewSorted(intSlice{5, 3, 1})
+	expected := []int{1, 3, 5}
+
+	check := func() {
+		t.Helper()
+		stdsort.Ints(expected)
+		if !intsEqual(expected, s.Slice()) {
+			t.Fatalf("%v != %v", s.Slice(), expected)
+		}
+		if s.Len() != len(expected) {
+			t.Fatalf("%d != %d", s.Len(), len(expected))
+		}
+	}
+	check()
+
+	for i := 0; i < 100; i++ {
+		switch rng.Intn(3) {
+		case 0:
+			v := rng.Intn(1000)
+			s.Insert(v)
+			expected = append(expected, v)
+		case 1:
+			vs := make([]int, rng.Intn(20))
+			for idx := range vs {
+				vs[idx] = rng.Intn(1000)
+			}
+			s.BulkInsert(vs)
+			expected = append(expected, vs...)
+		case 2:
+			if s.Len() == 0 {
+				continue
+			}
+			idx := rng.Intn(s.Len())
+			s.Remove(idx)
+			expected = append(expected[:idx], expected[idx+1:]...)
+		}
+		check()
+	}
+}
+
+func TestSortedZeroValue(t *testing.T) {
+	var s Sorted[int, intSlice]
+	s.Insert(2)
+	s.BulkInsert([]int{3, 1})
+	if !intsEqual(s.Slice(), []int{1, 2, 3}) {
+		t.Fatalf("unexpected content: %v", s.Slice())
+	}
+	s.Remove(0)
+	if !intsEqual(s.Slice(), []int{2, 3}) {
+		t.Fatalf("unexpected content: %v", s.Slice())
+	}
+}
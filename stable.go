@@ -0,0 +1,95 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"cmp"
+	stdslices "slices"
+	stdsort "sort"
+
+	"github.com/go-ng/slices"
+	"github.com/go-ng/sort"
+)
+
+// SortDescStable sorts the slice in descending order, preserving
+// the original order of equal elements.
+//
+// T: O(n*ln(n)*ln(n))
+//
+// S: O(1)
+func SortDescStable[E cmp.Ordered](s []E) {
+	stdslices.SortStableFunc(s, func(a, b E) int {
+		return cmp.Compare(b, a)
+	})
+}
+
+// StableDesc sorts the slice in descending order (according to Less),
+// preserving the original order of equal elements.
+//
+// T: O(n*ln(n)*ln(n))
+//
+// S: O(1)
+func StableDesc[E any, S Interface[E]](s S) {
+	stdsort.Stable(stdsort.Reverse(stdInterface[E, S](s)))
+}
+
+// StableFunc is the same as AppendedFunc, but it preserves the original
+// order of equal elements (elements of the tail are placed after equal
+// elements of the prefix). It mirrors `slices.SortStableFunc` with
+// the extra tailLength: passing `uint(len(s))` results into a plain
+// stable sort.
+//
+// T: O(k*ln(k)*ln(k) + k*ln(n) + n + k^2) -- thus if `k` is too high then: O(k^2)
+//
+// S: O(1) [if without `s`]
+func StableFunc[E any](s []E, tailLength uint, cmp func(a, b E) int) {
+	q := funcSeq[E]{s: s, less: func(a, b E) bool {
+		return cmp(a, b) < 0
+	}}
+	strategy := startAppendedSeq(q, tailLength, appendedPolicy{})
+	splitIdx := len(s) - int(tailLength)
+	switch strategy {
+	case StrategyAlreadySorted:
+		return
+	case StrategyFallbackSort:
+		stdslices.SortStableFunc(s, cmp)
+		return
+	}
+	stdslices.SortStableFunc(s[splitIdx:], cmp)
+	if strategy == StrategySortTail {
+		return
+	}
+
+	// The same idea as in groupInsertAppendSort, but the tail is sorted
+	// in ascending order and the block of unsorted elements is always
+	// contiguous, so the order of equal elements is kept.
+	unsortedStartIdx := splitIdx
+	unsortedEnd := len(s)
+	for unsortedStartIdx > 0 && unsortedEnd > unsortedStartIdx {
+		biggest := s[unsortedEnd-1]
+		leftIdx := sort.Search(unsortedStartIdx, func(i int) bool {
+			return cmp(s[i], biggest) > 0
+		})
+		unsortedCount := unsortedEnd - unsortedStartIdx
+		if leftIdx < unsortedStartIdx {
+			slices.Rotate(s[leftIdx:unsortedEnd], unsortedCount)
+			unsortedStartIdx = leftIdx
+		}
+		unsortedEnd = unsortedStartIdx + unsortedCount - 1
+	}
+}
+
+// AppendedDeterministic is the same as AppendedFunc with cmp.Compare, but
+// the ties are broken by the original position of the elements: equal
+// elements of the prefix go first (in their original order), followed by
+// the equal elements of the tail (in their original order). Thus
+// the result is reproducible even for elements which are equal but
+// distinguishable (like -0.0 and +0.0, or NaNs with different payloads).
+//
+// Breaking the ties by the original position is exactly a stable sort,
+// thus it is implemented through StableFunc.
+func AppendedDeterministic[E cmp.Ordered](s []E, tailLength uint) {
+	StableFunc(s, tailLength, cmp.Compare[E])
+}
@@ -0,0 +1,154 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	stdslices "slices"
+	stdsort "sort"
+	"testing"
+)
+
+type keySeq struct {
+	Key, Seq int
+}
+
+type keySeqs []keySeq
+
+func (s keySeqs) Less(i, j int) bool {
+	return s[i].Key < s[j].Key
+}
+
+func checkDescStable(t *testing.T, s []keySeq) {
+	for idx := 1; idx < len(s); idx++ {
+		prev, cur := s[idx-1], s[idx]
+		if prev.Key < cur.Key {
+			t.Fatalf("not descending at %d: %v", idx, s)
+		}
+		if prev.Key == cur.Key && prev.Seq > cur.Seq {
+			t.Fatalf("not stable at %d: %v", idx, s)
+		}
+	}
+}
+
+func randomKeySeqs() keySeqs {
+	rng := rand.New(rand.NewSource(0))
+	s := make(keySeqs, 1000)
+	for idx := range s {
+		s[idx] = keySeq{Key: rng.Intn(10), Seq: idx}
+	}
+	return s
+}
+
+func TestStableDesc(t *testing.T) {
+	s := randomKeySeqs()
+	StableDesc(s)
+	checkDescStable(t, s)
+}
+
+func TestSortDescStable(t *testing.T) {
+	s := []float64{1, 3, 2, 3, 1, 0}
+	SortDescStable(s)
+	expected := []float64{3, 3, 2, 1, 1, 0}
+	for idx := range expected {
+		if s[idx] != expected[idx] {
+			t.Fatalf("%v != %v", s, expected)
+		}
+	}
+}
+
+func testStableFunc(t *testing.T, s []keySeq, tailLength uint) {
+	t.Run(fmt.Sprintf("len-%d/tail-%d", len(s), tailLength), func(t *testing.T) {
+		splitIdx := len(s) - int(tailLength)
+		stdsort.SliceStable(s[:splitIdx], func(i, j int) bool {
+			return s[i].Key < s[j].Key
+		})
+		for idx := range s {
+			s[idx].Seq = idx
+		}
+
+		// a three-way comparator returning arbitrary magnitudes
+		StableFunc(s, tailLength, func(a, b keySeq) int {
+			switch {
+			case a.Key < b.Key:
+				return -(b.Key - a.Key) * 7
+			case a.Key > b.Key:
+				return (a.Key - b.Key) * 5
+			default:
+				return 0
+			}
+		})
+
+		for idx := 1; idx < len(s); idx++ {
+			prev, cur := s[idx-1], s[idx]
+			if prev.Key > cur.Key {
+				t.Fatalf("not sorted at %d: %v", idx, s)
+			}
+			if prev.Key == cur.Key && prev.Seq > cur.Seq {
+				t.Fatalf("not stable at %d: %v", idx, s)
+			}
+		}
+	})
+}
+
+func TestStableFunc(t *testing.T) {
+	testStableFunc(t, []keySeq{}, 0)
+	testStableFunc(t, []keySeq{{Key: 1}, {Key: 1}, {Key: 0}}, 3)
+	for _, tailLength := range []uint{0, 1, 5, 20, 100, 1000} {
+		testStableFunc(t, randomKeySeqs(), tailLength)
+	}
+}
+
+func FuzzStableFunc(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		s := make([]keySeq, len(initial))
+		for idx, v := range initial {
+			s[idx].Key = int(v % 16)
+		}
+		testStableFunc(t, s, uint(rand.Intn(len(initial)+1)))
+	})
+}
+
+func TestAppendedDeterministic(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	zeros := []float64{math.Copysign(0, -1), 0}
+	input := make([]float64, 1000)
+	for idx := range input {
+		input[idx] = zeros[rng.Intn(2)] + float64(rng.Intn(3))
+	}
+	stdsort.Float64s(input[:990])
+
+	var prev []uint64
+	for run := 0; run < 10; run++ {
+		s := append([]float64{}, input...)
+		AppendedDeterministic(s, 10)
+		if !stdsort.Float64sAreSorted(s) {
+			t.Fatalf("not sorted: %v", s)
+		}
+
+		bits := make([]uint64, len(s))
+		for idx, v := range s {
+			bits[idx] = math.Float64bits(v)
+		}
+		if prev != nil && !stdslices.Equal(prev, bits) {
+			t.Fatalf("the output differs between runs")
+		}
+		prev = bits
+	}
+
+	// the ties are broken by the original position
+	var expected []uint64
+	for _, v := range input {
+		expected = append(expected, math.Float64bits(v))
+	}
+	stdsort.SliceStable(expected, func(i, j int) bool {
+		return math.Float64frombits(expected[i]) < math.Float64frombits(expected[j])
+	})
+	if !stdslices.Equal(expected, prev) {
+		t.Fatalf("the ties are not broken by the original position")
+	}
+}
@@ -0,0 +1,64 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+// The labels of the branches of the group-insert reported
+// in AppendedStats.Branches.
+const (
+	// BranchReverse means the rest of the tail is less than all
+	// the elements of the prefix, so it was just reversed in place.
+	BranchReverse = "reverse"
+
+	// BranchRotate2 means the inserted element was placed right before
+	// the last element of the prefix (a rotation by 2). With a consistent
+	// Less it is not expected to happen, since the binary search would
+	// choose BranchBigRotate in this case.
+	BranchRotate2 = "rotate-2"
+
+	// BranchRotate1 means the inserted element was placed right after
+	// the prefix (a rotation by 1).
+	BranchRotate1 = "rotate-1"
+
+	// BranchBigRotate means the inserted element was placed inside
+	// the prefix, so the prefix elements greater than it were moved
+	// over the rest of the tail (a rotation of the whole range).
+	BranchBigRotate = "big-rotate"
+)
+
+// AppendedStats describes what AppendedWithStats did.
+type AppendedStats struct {
+	// Strategy is the label of the chosen strategy (see the Strategy*
+	// constants).
+	Strategy string
+
+	// Branches is the amount of executions of each branch of
+	// the group-insert (see the Branch* constants). It is empty unless
+	// Strategy is StrategyGroupInsert.
+	Branches map[string]int
+
+	// Comparisons is the amount of calls of Less (including the ones
+	// made by the sorting and by ValidateLess).
+	Comparisons int
+}
+
+// AppendedWithStats is the same as Appended, but it also returns
+// the statistics about the chosen strategy, the executed branches and
+// the amount of comparisons, which is useful to understand which branch
+// dominates for a given input distribution.
+//
+// It is slower than Appended, use it only for tuning.
+func AppendedWithStats[E any, S Interface[E]](s S, tailLength uint) AppendedStats {
+	stats := AppendedStats{
+		Branches: map[string]int{},
+	}
+	var counts Counts
+	q := countingSeq[E, S]{s: s, counts: &counts}
+	stats.Strategy = startAppendedSeq(q, tailLength, appendedPolicy{})
+	finishAppendedSeq(q, tailLength, stats.Strategy, func(branch string, _ uint) {
+		stats.Branches[branch]++
+	})
+	stats.Comparisons = int(counts.Less)
+	return stats
+}
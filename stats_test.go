@@ -0,0 +1,135 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"maps"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+func testAppendedWithStats(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		stats := AppendedWithStats(intSlice(s), tailLenght)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+		var steps int
+		for _, count := range stats.Branches {
+			steps += count
+		}
+		if steps > int(tailLenght) || (stats.Strategy != StrategyGroupInsert && steps != 0) {
+			t.Fatalf("unexpected stats: %v", stats)
+		}
+	})
+}
+
+func TestAppendedWithStats(t *testing.T) {
+	testAppendedWithStats(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedWithStats(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedWithStats(t, []byte{5, 4, 3, 2, 1}, 5)
+
+	for _, testCase := range []struct {
+		s        intSlice
+		expected AppendedStats
+	}{
+		{
+			s: intSlice{1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 21, 23, 25, 27, 29, 31, 10, 2},
+			expected: AppendedStats{
+				Strategy: StrategyGroupInsert,
+				Branches: map[string]int{BranchRotate1: 2, BranchBigRotate: 2},
+			},
+		},
+		{
+			s: intSlice{1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 21, 23, 25, 27, 29, 31, 32, 10, 0, -1},
+			expected: AppendedStats{
+				Strategy: StrategyGroupInsert,
+				Branches: map[string]int{BranchRotate1: 1, BranchBigRotate: 2, BranchReverse: 1},
+			},
+		},
+		{
+			s: intSlice{1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 21, 23, 25, 27, 29, 31, 31, 30, 0, 20},
+			expected: AppendedStats{
+				Strategy: StrategyGroupInsert,
+				Branches: map[string]int{BranchRotate1: 1, BranchBigRotate: 3},
+			},
+		},
+		{
+			s: intSlice{1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 21, 23, 25, 27, 29, 31, 32, 40, 35, 33},
+			expected: AppendedStats{
+				Strategy: StrategySortTail,
+				Branches: map[string]int{},
+			},
+		},
+	} {
+		stats := AppendedWithStats(testCase.s, 4)
+		if stats.Strategy != testCase.expected.Strategy || !maps.Equal(stats.Branches, testCase.expected.Branches) {
+			t.Fatalf("%v != %v", stats, testCase.expected)
+		}
+		if !stdsort.IntsAreSorted(testCase.s) {
+			t.Fatalf("not sorted: %v", testCase.s)
+		}
+	}
+}
+
+func FuzzAppendedWithStats(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedWithStats(t, initial, tailLenght)
+	})
+}
+
+func BenchmarkAppendedWithStats(b *testing.B) {
+	const (
+		totalSize = 65536
+		csCount   = 20
+	)
+	for _, tailSize := range []int{16, 1024} {
+		rng := rand.New(rand.NewSource(0))
+		in := make([][]int, csCount)
+		for idx := range in {
+			in[idx] = make([]int, totalSize)
+			s := in[idx]
+			for idx := range s {
+				s[idx] = rng.Intn(totalSize)
+			}
+			stdsort.Ints(s[:totalSize-tailSize])
+		}
+
+		cs := make([]intSlice, csCount)
+		for idx := range cs {
+			cs[idx] = make([]int, totalSize)
+		}
+
+		b.Run(fmt.Sprintf("total-%d/tail-%d", totalSize, tailSize), func(b *testing.B) {
+			branches := map[string]int{}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				idx := i % csCount
+				if idx == 0 {
+					b.StopTimer()
+					for idx := range cs {
+						copy(cs[idx], in[idx])
+					}
+					b.StartTimer()
+				}
+				for branch, count := range AppendedWithStats(cs[idx], uint(tailSize)).Branches {
+					branches[branch] += count
+				}
+			}
+			for branch, count := range branches {
+				b.ReportMetric(float64(count)/float64(b.N), branch+"/op")
+			}
+		})
+	}
+}
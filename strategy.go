@@ -0,0 +1,35 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+// The labels of the strategies passed to OnStrategy.
+const (
+	// StrategyAlreadySorted means the tail is empty, so nothing was done.
+	StrategyAlreadySorted = "already-sorted"
+
+	// StrategySortTail means none of the elements of the tail is less than
+	// the elements of the prefix, so only the tail was sorted.
+	StrategySortTail = "sort-tail"
+
+	// StrategyFallbackSort means the tail is too long for the optimization
+	// (or the slice is shorter than MinAppendedSize), so the whole slice
+	// was sorted.
+	StrategyFallbackSort = "fallback-sort"
+
+	// StrategyGroupInsert means the tail was merged into the prefix
+	// using the optimization (the group-insert of Appended, or the merge
+	// of the variant).
+	StrategyGroupInsert = "group-insert"
+)
+
+// OnStrategy (if not nil) is called once per each call of Appended
+// (or of any of its variants) with the label of the chosen strategy (see
+// the Strategy* constants) and the sizes of the slice and of the tail.
+// It is useful to collect metrics about strategy selection.
+//
+// It is not safe to modify OnStrategy concurrently with calls of
+// Appended; set it once on initialization. When it is nil the overhead
+// is a single comparison.
+var OnStrategy func(strategy string, totalSize, tailSize uint)
@@ -0,0 +1,187 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"cmp"
+	"fmt"
+	stdsort "sort"
+	"testing"
+)
+
+func TestOnStrategy(t *testing.T) {
+	type call struct {
+		strategy  string
+		totalSize uint
+		tailSize  uint
+	}
+	var calls []call
+	OnStrategy = func(strategy string, totalSize, tailSize uint) {
+		calls = append(calls, call{strategy, totalSize, tailSize})
+	}
+	defer func() { OnStrategy = nil }()
+
+	sorted := func(n int) intSlice {
+		s := make(intSlice, n)
+		for idx := range s {
+			s[idx] = idx * 2
+		}
+		return s
+	}
+
+	// all the in-place variants share the implementation of Appended,
+	// thus they should choose exactly the same strategies
+	variants := map[string]func(s intSlice, tailLength uint){
+		"Appended": func(s intSlice, tailLength uint) {
+			Appended(s, tailLength)
+		},
+		"AppendedIndexed": func(s intSlice, tailLength uint) {
+			AppendedIndexed(stdsort.IntSlice(s), tailLength)
+		},
+		"AppendedFunc": func(s intSlice, tailLength uint) {
+			AppendedFunc(s, tailLength, cmp.Compare[int])
+		},
+		"AppendedKV": func(s intSlice, tailLength uint) {
+			AppendedKV(s, make([]struct{}, len(s)), tailLength)
+		},
+		"AppendedWithStats": func(s intSlice, tailLength uint) {
+			AppendedWithStats(s, tailLength)
+		},
+		"StableFunc": func(s intSlice, tailLength uint) {
+			StableFunc(s, tailLength, cmp.Compare[int])
+		},
+		"AppendedBoundedStack": func(s intSlice, tailLength uint) {
+			AppendedBoundedStack(s, tailLength)
+		},
+		"AppendedWithProgress": func(s intSlice, tailLength uint) {
+			AppendedWithProgress(s, tailLength, func(done, total int) {})
+		},
+		"AppendedSelfLimiting": func(s intSlice, tailLength uint) {
+			AppendedSelfLimiting(s, tailLength)
+		},
+		"AppendedHeadTail": func(s intSlice, tailLength uint) {
+			AppendedHeadTail(s, 0, tailLength)
+		},
+		"AppendedRightward": func(s intSlice, tailLength uint) {
+			AppendedRightward(s, tailLength)
+		},
+		"AppendedWithSeed": func(s intSlice, tailLength uint) {
+			AppendedWithSeed(s, tailLength, 0)
+		},
+		"AppendedTailRuns": func(s intSlice, tailLength uint) {
+			AppendedTailRuns(s, []int{int(tailLength)})
+		},
+		"AppendedWithTailSorter": func(s intSlice, tailLength uint) {
+			AppendedWithTailSorter(s, tailLength, func(tail intSlice) {
+				stdsort.Ints(tail)
+			})
+		},
+		"AppendedStringRadix": func(s intSlice, tailLength uint) {
+			strs := make([]string, len(s))
+			for idx, v := range s {
+				strs[idx] = fmt.Sprintf("%08d", v)
+			}
+			AppendedStringRadix(strs, tailLength)
+		},
+	}
+	// the variants merging a tail of any length (see alwaysUseAppended)
+	// differ only in the strategy for the long tail
+	forcedVariants := map[string]func(s intSlice, tailLength uint){
+		"AppendedAdaptiveAbort": func(s intSlice, tailLength uint) {
+			AppendedAdaptiveAbort(s, tailLength)
+		},
+		"AppendedBlockMerge": func(s intSlice, tailLength uint) {
+			AppendedBlockMerge(s, tailLength)
+		},
+		"AppendedMergeParallel": func(s intSlice, tailLength uint) {
+			AppendedMergeParallel(s, tailLength, 2)
+		},
+	}
+	for _, group := range []struct {
+		variants         map[string]func(s intSlice, tailLength uint)
+		longTailStrategy string
+	}{
+		{variants: variants, longTailStrategy: StrategyFallbackSort},
+		{variants: forcedVariants, longTailStrategy: StrategyGroupInsert},
+	} {
+		for name, appended := range group.variants {
+			t.Run(name, func(t *testing.T) {
+				calls = calls[:0]
+
+				appended(sorted(100), 0)
+
+				s := sorted(100)
+				s[99] = 1000
+				appended(s, 1)
+
+				s = sorted(100)
+				s[99] = 1
+				appended(s, 1)
+
+				s = sorted(100)
+				s[10] = 1
+				appended(s, 90)
+
+				expected := []call{
+					{StrategyAlreadySorted, 100, 0},
+					{StrategySortTail, 100, 1},
+					{StrategyGroupInsert, 100, 1},
+					{group.longTailStrategy, 100, 90},
+				}
+				if len(calls) != len(expected) {
+					t.Fatalf("%v != %v", calls, expected)
+				}
+				for idx := range expected {
+					if calls[idx] != expected[idx] {
+						t.Fatalf("%v != %v", calls, expected)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestMinAppendedSize(t *testing.T) {
+	var strategies []string
+	OnStrategy = func(strategy string, totalSize, tailSize uint) {
+		strategies = append(strategies, strategy)
+	}
+	defer func() { OnStrategy = nil }()
+
+	// the last element is less than the others, so the group-insert
+	// would be used if the slice was big enough
+	appendedStrategy := func(length int) string {
+		strategies = strategies[:0]
+		s := make(intSlice, length)
+		for idx := range s {
+			s[idx] = idx + 1
+		}
+		s[length-1] = 0
+		Appended(s, 1)
+		if !stdsort.IntsAreSorted(s) {
+			t.Fatalf("not sorted: %v", s)
+		}
+		return strategies[0]
+	}
+
+	for length := 1; length < MinAppendedSize; length++ {
+		if strategy := appendedStrategy(length); strategy != StrategyFallbackSort {
+			t.Fatalf("unexpected strategy for length %d: %s", length, strategy)
+		}
+	}
+	if strategy := appendedStrategy(MinAppendedSize); strategy != StrategyGroupInsert {
+		t.Fatalf("unexpected strategy for length %d: %s", MinAppendedSize, strategy)
+	}
+
+	defer func(oldValue int) { MinAppendedSize = oldValue }(MinAppendedSize)
+	MinAppendedSize = 0
+	if strategy := appendedStrategy(5); strategy != StrategyGroupInsert {
+		t.Fatalf("unexpected strategy with MinAppendedSize == 0: %s", strategy)
+	}
+	MinAppendedSize = 1000
+	if strategy := appendedStrategy(100); strategy != StrategyFallbackSort {
+		t.Fatalf("unexpected strategy with MinAppendedSize == 1000: %s", strategy)
+	}
+}
@@ -0,0 +1,106 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+// radixInsertionSortThreshold is the size of a bucket, starting from which
+// radixSortStrings is used instead of the insertion sort.
+const radixInsertionSortThreshold = 32
+
+// AppendedStringRadix is the same as AppendedString, but if the tail is
+// too big for the appended optimization it sorts the whole slice using
+// an in-place MSD radix sort (American flag sort) instead of a comparison
+// sort. It is much faster for strings with long common prefixes (like
+// URLs or file paths), because the common prefixes are not compared again
+// and again.
+//
+// T: O(k*ln(n) + n + k^2) -- if `k` is small; and O(n*L) otherwise, where
+// `L` is the average length of distinguishing prefixes of the strings.
+//
+// S: O(L) [if without `s`]
+func AppendedStringRadix(s []string, tailLength uint) {
+	q := stringsSeq(s)
+	strategy := startAppendedSeq(q, tailLength, appendedPolicy{})
+	if strategy == StrategyFallbackSort {
+		radixSortStrings(s, 0)
+		return
+	}
+	finishAppendedSeq(q, tailLength, strategy, nil)
+}
+
+// radixSortStrings sorts s, assuming all the strings are equal in the first
+// `depth` bytes.
+func radixSortStrings(s []string, depth int) {
+	for len(s) >= radixInsertionSortThreshold {
+		// bucket 0 is for strings of length `depth`, bucket `b+1` is for
+		// strings with byte `b` at position `depth`.
+		var counts [257]int
+		for _, str := range s {
+			counts[radixBucket(str, depth)]++
+		}
+
+		if counts[0] == len(s) {
+			// all the strings are equal
+			return
+		}
+		if oneBucket := counts[radixBucket(s[0], depth)] == len(s); oneBucket {
+			depth++
+			continue
+		}
+
+		var starts, ends [257]int
+		offset := 0
+		for bucket, count := range counts {
+			starts[bucket] = offset
+			offset += count
+			ends[bucket] = offset
+		}
+
+		// permute the strings in-place, following the cycles
+		for bucket := range counts {
+			for starts[bucket] < ends[bucket] {
+				str := s[starts[bucket]]
+				dstBucket := radixBucket(str, depth)
+				for dstBucket != bucket {
+					str, s[starts[dstBucket]] = s[starts[dstBucket]], str
+					starts[dstBucket]++
+					dstBucket = radixBucket(str, depth)
+				}
+				s[starts[bucket]] = str
+				starts[bucket]++
+			}
+		}
+
+		// bucket 0 contains equal strings, so it is already sorted
+		start := counts[0]
+		for _, count := range counts[1:] {
+			if count > 1 {
+				radixSortStrings(s[start:start+count], depth+1)
+			}
+			start += count
+		}
+		return
+	}
+
+	insertionSortStrings(s, depth)
+}
+
+// radixBucket returns the bucket of the string for the given depth, see
+// radixSortStrings.
+func radixBucket(str string, depth int) int {
+	if depth >= len(str) {
+		return 0
+	}
+	return int(str[depth]) + 1
+}
+
+// insertionSortStrings sorts s, assuming all the strings are equal in
+// the first `depth` bytes.
+func insertionSortStrings(s []string, depth int) {
+	for i := 1; i < len(s); i++ {
+		for j := i; j > 0 && s[j][depth:] < s[j-1][depth:]; j-- {
+			s[j], s[j-1] = s[j-1], s[j]
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+// urlLike returns a URL-like string with a long common prefix, which
+// depends on the given value.
+func urlLike(v byte) string {
+	return fmt.Sprintf("https://example.com/api/v1/%s/%d", strings.Repeat("x", int(v%4)), v/4)
+}
+
+func testAppendedStringRadix(t *testing.T, initial []byte, tailLenght uint) {
+	s := make([]string, len(initial))
+	for idx, v := range initial {
+		s[idx] = urlLike(v)
+	}
+	stdsort.Strings(s[:len(s)-int(tailLenght)])
+	c := make([]string, len(s))
+	copy(c, s)
+	t.Run(fmt.Sprintf("total-%d/tail-%d", len(s), tailLenght), func(t *testing.T) {
+		AppendedStringRadix(s, tailLenght)
+		stdsort.Strings(c)
+		if !Equal(c, s) {
+			t.Fatalf("%v != %v", c, s)
+		}
+	})
+}
+
+func TestAppendedStringRadix(t *testing.T) {
+	testAppendedStringRadix(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedStringRadix(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+
+	rng := rand.New(rand.NewSource(0))
+	initial := make([]byte, 1000)
+	rng.Read(initial)
+	for _, tailLength := range []uint{1, 10, 100, 1000} {
+		testAppendedStringRadix(t, initial, tailLength)
+	}
+
+	t.Run("radixSortStrings", func(t *testing.T) {
+		s := make([]string, 1000)
+		for idx := range s {
+			b := make([]byte, rng.Intn(5))
+			for idx := range b {
+				b[idx] = byte(rng.Intn(3)) * 127
+			}
+			s[idx] = string(b)
+		}
+		c := make([]string, len(s))
+		copy(c, s)
+		radixSortStrings(s, 0)
+		stdsort.Strings(c)
+		if !Equal(c, s) {
+			t.Fatalf("%q != %q", c, s)
+		}
+	})
+}
+
+func FuzzAppendedStringRadix(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedStringRadix(t, initial, tailLenght)
+	})
+}
+
+func BenchmarkAppendedStringRadix(b *testing.B) {
+	const (
+		totalSize = 65536
+		csCount   = 20
+	)
+	for _, tailSize := range []int{16, 1024, 65536} {
+		rng := rand.New(rand.NewSource(0))
+		in := make([][]string, csCount)
+		for idx := range in {
+			in[idx] = make([]string, totalSize)
+			s := in[idx]
+			for idx := range s {
+				s[idx] = fmt.Sprintf("https://example.com/static/images/%d/%d.png", rng.Intn(64), rng.Intn(totalSize))
+			}
+			stdsort.Strings(s[:totalSize-tailSize])
+		}
+
+		cs := make([][]string, csCount)
+		for idx := range cs {
+			cs[idx] = make([]string, totalSize)
+		}
+
+		for _, f := range []struct {
+			name string
+			fn   func([]string, uint)
+		}{
+			{name: "Appended", fn: func(s []string, tailLength uint) {
+				Appended(stdsort.StringSlice(s), tailLength)
+			}},
+			{name: "AppendedStringRadix", fn: AppendedStringRadix},
+		} {
+			b.Run(fmt.Sprintf("total-%d/tail-%d/%s", totalSize, tailSize, f.name), func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range cs {
+							copy(cs[idx], in[idx])
+						}
+						b.StartTimer()
+					}
+					f.fn(cs[idx], uint(tailSize))
+				}
+			})
+		}
+	}
+}
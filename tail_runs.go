@@ -0,0 +1,52 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+
+	"github.com/go-ng/slices"
+	"github.com/go-ng/sort"
+)
+
+// AppendedTailRuns is the same as Appended, but the unsorted tail is known
+// to consist of adjacent sorted runs (for example produced by parallel
+// producers) with lengths tailRunLengths. The tail length is the sum
+// of tailRunLengths.
+//
+// Instead of sorting the tail from scratch, the runs are merged
+// (see MergeRuns), and then the merged tail is inserted into the prefix.
+//
+// T: O(k*ln(r) + k*ln(n) + n + k^2), where `r` is the amount of runs
+//
+// S: O(k) [if without `s`]
+func AppendedTailRuns[E any, S Interface[E]](s S, tailRunLengths []int) {
+	tailLength := 0
+	for idx, runLength := range tailRunLengths {
+		if runLength < 0 {
+			panic(fmt.Sprintf("the length of run #%d is negative: %d", idx, runLength))
+		}
+		// compared with the rest of the slice instead of summing first,
+		// so the sum cannot overflow
+		if runLength > len(s)-tailLength {
+			panic(fmt.Sprintf("the sum of tailRunLengths cannot be greater than the length of the provided slice (%d)", len(s)))
+		}
+		tailLength += runLength
+	}
+
+	strategy := startAppended(s, uint(tailLength), appendedPolicy{})
+	splitIdx := len(s) - tailLength
+	tail := s[splitIdx:]
+	switch strategy {
+	case StrategyFallbackSort:
+		sort.Sort(s)
+	case StrategySortTail:
+		MergeRuns(tail, tailRunLengths)
+	case StrategyGroupInsert:
+		MergeRuns(tail, tailRunLengths)
+		slices.Reverse(tail)
+		groupInsertDescendingTail(s, uint(splitIdx))
+	}
+}
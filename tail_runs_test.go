@@ -0,0 +1,119 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+func testAppendedTailRuns(t *testing.T, initial []byte, tailRunLengths []int) {
+	tailLenght := 0
+	for _, runLength := range tailRunLengths {
+		tailLenght += runLength
+	}
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, uint(tailLenght))
+	start := len(s) - tailLenght
+	for _, runLength := range tailRunLengths {
+		stdsort.Ints(s[start : start+runLength])
+		start += runLength
+	}
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(fmt.Sprintf("%s/runs-%v", testName, tailRunLengths), func(t *testing.T) {
+		AppendedTailRuns(intSlice(s), tailRunLengths)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedTailRuns(t *testing.T) {
+	testAppendedTailRuns(t, []byte{}, nil)
+	testAppendedTailRuns(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, []int{2, 2})
+	testAppendedTailRuns(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, []int{1, 0, 3})
+	testAppendedTailRuns(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 16, 18, 20, 21}, []int{4})
+	testAppendedTailRuns(t, []byte{5, 4, 3, 2, 1}, []int{1, 1, 1, 1, 1})
+	testAppendedTailRuns(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 14, 12, 8, 1}, []int{1, 1, 1, 1})
+
+	t.Run("invalid_lengths", func(t *testing.T) {
+		expectPanic(t, "cannot be greater", func() {
+			AppendedTailRuns(intSlice{1, 2, 3}, []int{2, 2})
+		})
+		expectPanic(t, "cannot be greater", func() {
+			AppendedTailRuns(intSlice{1, 2, 3}, []int{2, math.MaxInt})
+		})
+		expectPanic(t, "negative", func() {
+			AppendedTailRuns(intSlice{1, 2, 3}, []int{2, -1})
+		})
+	})
+}
+
+func FuzzAppendedTailRuns(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		testAppendedTailRuns(t, initial, randomRunLengths(rand.New(rand.NewSource(int64(len(initial)))), rand.Intn(len(initial)+1), 8))
+	})
+}
+
+func BenchmarkAppendedTailRuns(b *testing.B) {
+	const (
+		totalSize = 65536
+		csCount   = 20
+		runsCount = 4
+	)
+	for _, tailSize := range []int{64, 1024} {
+		rng := rand.New(rand.NewSource(0))
+		in := make([][]int, csCount)
+		for idx := range in {
+			in[idx] = make([]int, totalSize)
+			s := in[idx]
+			for idx := range s {
+				s[idx] = rng.Intn(totalSize)
+			}
+			stdsort.Ints(s[:totalSize-tailSize])
+			for start := totalSize - tailSize; start < totalSize; start += tailSize / runsCount {
+				stdsort.Ints(s[start : start+tailSize/runsCount])
+			}
+		}
+		tailRunLengths := make([]int, runsCount)
+		for idx := range tailRunLengths {
+			tailRunLengths[idx] = tailSize / runsCount
+		}
+
+		cs := make([]intSlice, csCount)
+		for idx := range cs {
+			cs[idx] = make([]int, totalSize)
+		}
+
+		for _, f := range []struct {
+			name string
+			fn   func(intSlice)
+		}{
+			{name: "Appended", fn: func(s intSlice) { Appended(s, uint(tailSize)) }},
+			{name: "AppendedTailRuns", fn: func(s intSlice) { AppendedTailRuns(s, tailRunLengths) }},
+		} {
+			b.Run(fmt.Sprintf("total-%d/tail-%d/runs-%d/%s", totalSize, tailSize, runsCount, f.name), func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range cs {
+							copy(cs[idx], in[idx])
+						}
+						b.StartTimer()
+					}
+					f.fn(cs[idx])
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"github.com/go-ng/slices"
+	"github.com/go-ng/sort"
+)
+
+// AppendedWithTailSorter is the same as Appended, but the unsorted tail
+// is sorted by sortTail (in ascending order), for example by a radix sort
+// for primitive types. The merge of the sorted tail into the prefix is
+// the same as in Appended.
+//
+// sortTail is called only with the tail region of s (`s[len(s)-tailLength:]`).
+// If the tail is the whole slice, then the tail region is the whole s,
+// so the whole s is sorted by sortTail. Otherwise if the tail is too
+// long for the optimization (or the slice is shorter than
+// MinAppendedSize), then the whole slice is sorted by `sort.Sort` and
+// sortTail is not called (the same as in AppendedWithHint).
+//
+// T: O(n + k*ln(n)) + T(sortTail)
+//
+// S: O(1) + S(sortTail) [if without `s`]
+func AppendedWithTailSorter[E any, S Interface[E]](s S, tailLength uint, sortTail func(S)) {
+	strategy := startAppended(s, tailLength, appendedPolicy{})
+	splitIdx := uint(len(s)) - tailLength
+	tail := s[splitIdx:]
+	switch strategy {
+	case StrategyFallbackSort:
+		if splitIdx == 0 {
+			sortTail(s)
+			return
+		}
+		sort.Sort(s)
+	case StrategySortTail:
+		sortTail(tail)
+	case StrategyGroupInsert:
+		sortTail(tail)
+		slices.Reverse(tail)
+		groupInsertDescendingTail(s, splitIdx)
+	}
+}
@@ -0,0 +1,126 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+func testAppendedWithTailSorter(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		AppendedWithTailSorter(intSlice(s), tailLenght, func(tail intSlice) {
+			stdsort.Ints(tail)
+		})
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedWithTailSorter(t *testing.T) {
+	testAppendedWithTailSorter(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedWithTailSorter(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+	testAppendedWithTailSorter(t, []byte{5, 4, 3, 2, 1}, 5)
+
+	t.Run("invoked_on_tail", func(t *testing.T) {
+		const tailLength = 10
+		s := make(intSlice, 1000)
+		for idx := range s {
+			s[idx] = idx * 2
+		}
+		for idx := len(s) - tailLength; idx < len(s); idx++ {
+			s[idx] = rand.Intn(2 * len(s))
+		}
+
+		var calls int
+		AppendedWithTailSorter(s, tailLength, func(tail intSlice) {
+			calls++
+			if len(tail) != tailLength || &tail[0] != &s[len(s)-tailLength] {
+				t.Fatalf("sortTail is called not for the tail region: length %d", len(tail))
+			}
+			stdsort.Ints(tail)
+		})
+		if calls != 1 {
+			t.Fatalf("unexpected amount of sortTail calls: %d", calls)
+		}
+		if !stdsort.IntsAreSorted(s) {
+			t.Fatalf("not sorted: %v", s)
+		}
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		s := make(intSlice, 1000)
+		for idx := range s {
+			s[idx] = rand.Intn(len(s))
+		}
+		AppendedWithTailSorter(s, uint(len(s)-1), func(intSlice) {
+			t.Fatal("sortTail is not expected to be called on fallback")
+		})
+		if !stdsort.IntsAreSorted(s) {
+			t.Fatalf("not sorted: %v", s)
+		}
+	})
+}
+
+func FuzzAppendedWithTailSorter(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedWithTailSorter(t, initial, tailLenght)
+	})
+}
+
+func BenchmarkAppendedWithTailSorter(b *testing.B) {
+	const (
+		totalSize = 65536
+		tailSize  = 1024
+		csCount   = 20
+	)
+	rng := rand.New(rand.NewSource(0))
+	in := make([][]int, csCount)
+	for idx := range in {
+		in[idx] = make([]int, totalSize)
+		s := in[idx]
+		for idx := range s {
+			s[idx] = rng.Intn(totalSize)
+		}
+		stdsort.Ints(s[:totalSize-tailSize])
+	}
+	cs := make([]intSlice, csCount)
+	for idx := range cs {
+		cs[idx] = make([]int, totalSize)
+	}
+
+	for _, f := range []struct {
+		name string
+		fn   func(intSlice)
+	}{
+		{name: "Appended", fn: func(s intSlice) { Appended(s, tailSize) }},
+		{name: "AppendedWithTailSorter-stdsort.Ints", fn: func(s intSlice) {
+			AppendedWithTailSorter(s, tailSize, func(tail intSlice) { stdsort.Ints(tail) })
+		}},
+	} {
+		b.Run(fmt.Sprintf("total-%d/tail-%d/%s", totalSize, tailSize, f.name), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				idx := i % csCount
+				if idx == 0 {
+					b.StopTimer()
+					for idx := range cs {
+						copy(cs[idx], in[idx])
+					}
+					b.StartTimer()
+				}
+				f.fn(cs[idx])
+			}
+		})
+	}
+}
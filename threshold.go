@@ -0,0 +1,31 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+// AppendedWithThreshold is the same as Appended, but the caller decides
+// whether to use the optimization, instead of the built-in estimation
+// (which assumes cheap comparisons and cheap moves). If useOptimization
+// is false, then the whole slice is just sorted. Slices shorter than
+// MinAppendedSize are sorted in any case. The forced choice is reported
+// to OnStrategy the same way as the choice of Appended.
+//
+// It is an escape hatch for callers who have measured their specific
+// workload, for example if Less is very expensive (and thus the amount
+// of comparisons matters more than the amount of moves).
+//
+// Forcing the optimization on a long tail risks the quadratic behavior
+// (see the `k^2` term below); consider AppendedSelfLimiting instead.
+//
+// T: O(k*ln(n) + n + k^2) [if useOptimization], otherwise O(n*ln(n))
+//
+// S: O(1) [if without `s`]
+func AppendedWithThreshold[E any, S Interface[E]](s S, tailLength uint, useOptimization bool) {
+	policy := appendedPolicy{shouldUse: alwaysUseAppended}
+	if !useOptimization {
+		policy = appendedPolicy{shouldUse: neverUseAppended, noSortTail: true}
+	}
+	strategy := startAppended(s, tailLength, policy)
+	finishAppended(s, tailLength, strategy)
+}
@@ -0,0 +1,135 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdslices "slices"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+func testAppendedWithThreshold(t *testing.T, initial []byte, tailLenght uint, useOptimization bool) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(fmt.Sprintf("%s/useOptimization-%t", testName, useOptimization), func(t *testing.T) {
+		AppendedWithThreshold(intSlice(s), tailLenght, useOptimization)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestAppendedWithThreshold(t *testing.T) {
+	for _, useOptimization := range []bool{true, false} {
+		testAppendedWithThreshold(t, []byte{}, 0, useOptimization)
+		testAppendedWithThreshold(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4, useOptimization)
+		testAppendedWithThreshold(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4, useOptimization)
+		testAppendedWithThreshold(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 16, 18, 20, 21}, 4, useOptimization)
+		testAppendedWithThreshold(t, []byte{5, 4, 3, 2, 1}, 5, useOptimization)
+		testAppendedWithThreshold(t, []byte{3, 2, 1}, 2, useOptimization)
+	}
+
+	t.Run("comparisons", func(t *testing.T) {
+		// a tail too long for Appended, but the caller prefers less comparisons
+		values := make([]int, 4096)
+		rng := rand.New(rand.NewSource(0))
+		for idx := range values {
+			values[idx] = rng.Intn(len(values))
+		}
+		stdsort.Ints(values[:len(values)-1024])
+		if shouldUseAppended(uint(len(values)), 1024) {
+			t.Fatal("the test case is expected to fail the built-in estimation")
+		}
+
+		forced, forcedCount := newCountedInts(values)
+		AppendedWithThreshold(forced, 1024, true)
+		fallback, fallbackCount := newCountedInts(values)
+		AppendedWithThreshold(fallback, 1024, false)
+		if *forcedCount >= *fallbackCount {
+			t.Fatalf("expected less comparisons with the optimization: %d >= %d", *forcedCount, *fallbackCount)
+		}
+		for idx := 1; idx < len(forced); idx++ {
+			if forced[idx].v < forced[idx-1].v {
+				t.Fatalf("not sorted at %d", idx)
+			}
+		}
+	})
+
+	t.Run("OnStrategy", func(t *testing.T) {
+		var strategies []string
+		OnStrategy = func(strategy string, totalSize, tailSize uint) {
+			strategies = append(strategies, strategy)
+		}
+		defer func() { OnStrategy = nil }()
+
+		s := make(intSlice, 100)
+		for idx := range s {
+			s[idx] = idx * 2
+		}
+		s[10] = 1
+		AppendedWithThreshold(append(intSlice{}, s...), 90, true)
+		AppendedWithThreshold(append(intSlice{}, s...), 90, false)
+		AppendedWithThreshold(append(intSlice{}, s...), 1, false)
+		AppendedWithThreshold(append(intSlice{}, s[:10]...), 1, true)
+		expected := []string{StrategyGroupInsert, StrategyFallbackSort, StrategyFallbackSort, StrategyFallbackSort}
+		if !stdslices.Equal(strategies, expected) {
+			t.Fatalf("%v != %v", strategies, expected)
+		}
+	})
+}
+
+func FuzzAppendedWithThreshold(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedWithThreshold(t, initial, tailLenght, rand.Intn(2) == 0)
+	})
+}
+
+func BenchmarkAppendedWithThreshold(b *testing.B) {
+	const (
+		totalSize = 65536
+		csCount   = 20
+	)
+	for _, tailSize := range []int{1024, 4096} {
+		rng := rand.New(rand.NewSource(0))
+		in := make([][]int, csCount)
+		for idx := range in {
+			in[idx] = make([]int, totalSize)
+			s := in[idx]
+			for idx := range s {
+				s[idx] = rng.Intn(totalSize)
+			}
+			stdsort.Ints(s[:totalSize-tailSize])
+		}
+
+		cs := make([]intSlice, csCount)
+		for idx := range cs {
+			cs[idx] = make([]int, totalSize)
+		}
+
+		for _, useOptimization := range []bool{true, false} {
+			b.Run(fmt.Sprintf("total-%d/tail-%d/useOptimization-%t", totalSize, tailSize, useOptimization), func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range cs {
+							copy(cs[idx], in[idx])
+						}
+						b.StartTimer()
+					}
+					AppendedWithThreshold(cs[idx], uint(tailSize), useOptimization)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	stdsort "sort"
+
+	"github.com/go-ng/sort"
+)
+
+// AppendedTracked is the same as Appended, but it also returns the final
+// indexes of the tail elements: the i-th returned value is the index of
+// the element, which was at index `len(s)-tailLength+i` before sorting.
+// It is useful to update an auxiliary structure (like an index map)
+// after inserting a batch of elements.
+//
+// Unlike Appended, the result is stable (equal elements keep their
+// original order) and it requires O(k) additional memory.
+//
+// T: O(k*ln(n) + n)
+//
+// S: O(k) [if without `s`]
+func AppendedTracked[E any, S Interface[E]](s S, tailLength uint) []int {
+	// the strategy is only checked and reported: the tail is always
+	// merged the same way, since all the positions have to be recorded
+	startAppended(s, tailLength, appendedPolicy{shouldUse: alwaysUseAppended, noSortTail: true})
+	splitIdx := len(s) - int(tailLength)
+
+	// order is the tail indexes (relative to splitIdx) in the sorted order
+	// of the tail elements.
+	order := make([]int, tailLength)
+	for idx := range order {
+		order[idx] = idx
+	}
+	stdsort.SliceStable(order, func(i, j int) bool {
+		return s.Less(splitIdx+order[i], splitIdx+order[j])
+	})
+	buf := make([]E, tailLength)
+	for idx, tailIdx := range order {
+		buf[idx] = s[splitIdx+tailIdx]
+	}
+
+	// The same as groupInsertAppendSortWithBuf, but it also records
+	// the final positions.
+	positions := make([]int, tailLength)
+	unsortedStartIdx := splitIdx
+	unsortedEnd := len(s)
+	for unsortedCount := int(tailLength); unsortedCount > 0; unsortedCount-- {
+		s[unsortedStartIdx] = buf[unsortedCount-1]
+		leftIdx := sort.Search(unsortedStartIdx, func(i int) bool {
+			return s.Less(unsortedStartIdx, i)
+		})
+
+		copy(s[leftIdx+unsortedCount:unsortedEnd], s[leftIdx:])
+		s[leftIdx+unsortedCount-1] = buf[unsortedCount-1]
+		positions[order[unsortedCount-1]] = leftIdx + unsortedCount - 1
+
+		unsortedStartIdx = leftIdx
+		unsortedEnd = unsortedStartIdx + unsortedCount - 1
+	}
+	return positions
+}
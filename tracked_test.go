@@ -0,0 +1,131 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"testing"
+)
+
+func testAppendedTracked(t *testing.T, initial []byte, tailLenght uint) {
+	// Seq is the original index, thus it identifies the elements
+	s := make(keySeqs, len(initial))
+	for idx, v := range initial {
+		s[idx] = keySeq{Key: int(v), Seq: idx}
+	}
+	splitIdx := len(s) - int(tailLenght)
+	stdsort.SliceStable(s[:splitIdx], func(i, j int) bool {
+		return s[i].Key < s[j].Key
+	})
+	tail := append(keySeqs{}, s[splitIdx:]...)
+
+	t.Run(fmt.Sprintf("%v/tail-%d", initial, tailLenght), func(t *testing.T) {
+		positions := AppendedTracked(s, tailLenght)
+		if len(positions) != len(tail) {
+			t.Fatalf("unexpected amount of positions: %d != %d", len(positions), len(tail))
+		}
+		for idx, pos := range positions {
+			if s[pos] != tail[idx] {
+				t.Fatalf("tail element #%d (%v) is not at %d: %v", idx, tail[idx], pos, s)
+			}
+		}
+		for idx := 1; idx < len(s); idx++ {
+			if s[idx].Key < s[idx-1].Key {
+				t.Fatalf("not sorted at %d: %v", idx, s)
+			}
+		}
+	})
+}
+
+func TestAppendedTracked(t *testing.T) {
+	testAppendedTracked(t, []byte{}, 0)
+	testAppendedTracked(t, []byte{1, 2, 3}, 0)
+	testAppendedTracked(t, []byte{3, 2, 1}, 3)
+	testAppendedTracked(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testAppendedTracked(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+
+	t.Run("hand-computed", func(t *testing.T) {
+		s := intSlice{10, 20, 30, 40, 25, 5, 35}
+		positions := AppendedTracked(s, 3)
+		if !Equal(s, []int{5, 10, 20, 25, 30, 35, 40}) {
+			t.Fatalf("unexpected result: %v", s)
+		}
+		if !Equal(positions, []int{3, 0, 5}) {
+			t.Fatalf("unexpected positions: %v", positions)
+		}
+	})
+
+	t.Run("stable", func(t *testing.T) {
+		s := randomKeySeqs()
+		stdsort.SliceStable(s[:900], func(i, j int) bool {
+			return s[i].Key < s[j].Key
+		})
+		AppendedTracked(s, 100)
+		for idx := 1; idx < len(s); idx++ {
+			if s[idx].Key == s[idx-1].Key && s[idx].Seq < s[idx-1].Seq {
+				t.Fatalf("not stable at %d: %v", idx, s)
+			}
+		}
+	})
+}
+
+func FuzzAppendedTracked(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testAppendedTracked(t, initial, tailLenght)
+	})
+}
+
+func BenchmarkAppendedTracked(b *testing.B) {
+	const (
+		totalSize = 65536
+		csCount   = 20
+	)
+	for _, tailSize := range []int{16, 1024} {
+		rng := rand.New(rand.NewSource(0))
+		in := make([][]int, csCount)
+		for idx := range in {
+			in[idx] = make([]int, totalSize)
+			s := in[idx]
+			for idx := range s {
+				s[idx] = rng.Intn(totalSize)
+			}
+			stdsort.Ints(s[:totalSize-tailSize])
+		}
+
+		cs := make([]intSlice, csCount)
+		for idx := range cs {
+			cs[idx] = make([]int, totalSize)
+		}
+
+		for _, f := range []struct {
+			name string
+			fn   func(intSlice, uint)
+		}{
+			{name: "Appended", fn: Appended[int, intSlice]},
+			{name: "AppendedTracked", fn: func(s intSlice, tailLength uint) {
+				AppendedTracked(s, tailLength)
+			}},
+		} {
+			b.Run(fmt.Sprintf("total-%d/tail-%d/%s", totalSize, tailSize, f.name), func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range cs {
+							copy(cs[idx], in[idx])
+						}
+						b.StartTimer()
+					}
+					f.fn(cs[idx], uint(tailSize))
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+// AppendedUnique sorts the slice the same way as Appended does and then
+// removes duplicates (elements for which neither is less than the other),
+// keeping the first of equal elements. The resulting (shortened) slice is
+// returned; the elements after its end are zeroed.
+//
+// It is useful to maintain a sorted set: the prefix is a set and the tail
+// is a bunch of candidates, which may duplicate the members of the set or
+// each other.
+//
+// T: O(k*ln(n) + n + k^2) -- thus if `k` is too high then: O(k^2)
+//
+// S: O(1) [if without `s`]
+func AppendedUnique[E any, S Interface[E]](s S, tailLength uint) S {
+	Appended(s, tailLength)
+	if len(s) == 0 {
+		return s
+	}
+
+	outIdx := 1
+	for idx := 1; idx < len(s); idx++ {
+		if !s.Less(outIdx-1, idx) {
+			continue
+		}
+		s[outIdx] = s[idx]
+		outIdx++
+	}
+	clear(s[outIdx:])
+	return s[:outIdx]
+}
+
+// AppendedUniqueFunc is the same as AppendedUnique, but uses the provided
+// function to check if two elements are equal. It is useful if the order
+// defined by Less is not total.
+//
+// eq should be consistent with Less: equal elements should be neighbours
+// in a sorted slice.
+func AppendedUniqueFunc[E any, S Interface[E]](s S, tailLength uint, eq func(a, b E) bool) S {
+	Appended(s, tailLength)
+	if len(s) == 0 {
+		return s
+	}
+
+	outIdx := 1
+	for idx := 1; idx < len(s); idx++ {
+		if eq(s[outIdx-1], s[idx]) {
+			continue
+		}
+		s[outIdx] = s[idx]
+		outIdx++
+	}
+	clear(s[outIdx:])
+	return s[:outIdx]
+}
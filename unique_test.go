@@ -0,0 +1,97 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	stdsort "sort"
+	"testing"
+)
+
+// prepareUniqueTestCase returns a slice consisting of the deduplicated
+// and sorted values of `prefix` followed by the values of `tail`, and the
+// expected result (calculated through a map).
+func prepareUniqueTestCase(prefix, tail []byte) ([]int, []int) {
+	set := map[int]struct{}{}
+	var s []int
+	for _, v := range prefix {
+		if _, ok := set[int(v)]; ok {
+			continue
+		}
+		set[int(v)] = struct{}{}
+		s = append(s, int(v))
+	}
+	stdsort.Ints(s)
+	for _, v := range tail {
+		set[int(v)] = struct{}{}
+		s = append(s, int(v))
+	}
+
+	expected := make([]int, 0, len(set))
+	for v := range set {
+		expected = append(expected, v)
+	}
+	stdsort.Ints(expected)
+	return s, expected
+}
+
+// taggedValue is a non-comparable type (because of the slice field).
+type taggedValue struct {
+	Value int
+	Tags  []string
+}
+
+type taggedValues []taggedValue
+
+func (s taggedValues) Less(i, j int) bool {
+	return s[i].Value < s[j].Value
+}
+
+func testAppendedUnique(t *testing.T, prefix, tail []byte) {
+	s, expected := prepareUniqueTestCase(prefix, tail)
+	t.Run(fmt.Sprintf("%v (tailLength: %d)", s, len(tail)), func(t *testing.T) {
+		orig := append([]int{}, s...)
+		r := AppendedUnique(intSlice(s), uint(len(tail)))
+		if !intsEqual(expected, r) {
+			t.Fatalf("%v != %v; testCase: %v", expected, r, orig)
+		}
+		for _, v := range s[len(r):] {
+			if v != 0 {
+				t.Fatalf("the elements after the end are not zeroed: %v", s)
+			}
+		}
+
+		tv := make(taggedValues, len(orig))
+		for idx, v := range orig {
+			tv[idx] = taggedValue{Value: v, Tags: []string{fmt.Sprint(idx)}}
+		}
+		tv = AppendedUniqueFunc(tv, uint(len(tail)), func(a, b taggedValue) bool {
+			return a.Value == b.Value
+		})
+		if len(tv) != len(expected) {
+			t.Fatalf("%d != %d; testCase: %v", len(tv), len(expected), orig)
+		}
+		for idx := range tv {
+			if tv[idx].Value != expected[idx] {
+				t.Fatalf("%v != %v; testCase: %v", tv, expected, orig)
+			}
+		}
+	})
+}
+
+func TestAppendedUnique(t *testing.T) {
+	testAppendedUnique(t, nil, nil)
+	testAppendedUnique(t, []byte{1}, nil)
+	testAppendedUnique(t, nil, []byte{3, 1, 3, 2, 1})
+	testAppendedUnique(t, []byte{1, 3, 5, 7, 11, 13}, []byte{12, 6, 4, 7})
+	testAppendedUnique(t, []byte{1, 3, 5, 7, 11, 13}, []byte{13, 1, 1, 13})
+	testAppendedUnique(t, []byte{0, 2, 5, 8, 9, 10, 11, 15, 17, 20, 21, 22}, []byte{8, 12, 8, 14})
+}
+
+func FuzzAppendedUnique(f *testing.F) {
+	f.Fuzz(func(t *testing.T, prefix, tail []byte) {
+		testAppendedUnique(t, prefix, tail)
+	})
+}
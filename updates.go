@@ -0,0 +1,76 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	stdsort "sort"
+)
+
+// Update is a replacement of an element of a sorted slice,
+// see AppendedUpdates.
+type Update[E any] struct {
+	// Index is the index of the replaced element in the sorted slice
+	// (before any of the updates are applied).
+	Index int
+
+	// Value is the new value of the element.
+	Value E
+}
+
+// AppendedUpdates replaces the elements of the sorted slice according
+// to updates and sorts the slice again. If multiple updates have the same
+// Index, then the last one wins. The length of the slice is not changed.
+//
+// The updated elements are moved to the end of the slice (keeping
+// the rest of the slice sorted), and then sorted by Appended, which is
+// much faster than a full sort if there are few updates.
+//
+// It panics if an Index is out of range.
+//
+// T: O(u*ln(u) + n) + the same as Appended with `k = u`
+//
+// S: O(u)
+func AppendedUpdates[E any, S Interface[E]](s S, updates []Update[E]) {
+	if len(updates) == 0 {
+		return
+	}
+	for _, update := range updates {
+		if update.Index < 0 || update.Index >= len(s) {
+			panic(fmt.Sprintf("update index (%d) is out of range [0, %d)", update.Index, len(s)))
+		}
+	}
+
+	sorted := make([]Update[E], len(updates))
+	copy(sorted, updates)
+	stdsort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Index < sorted[j].Index
+	})
+	// deduplicate, the last update of an index wins
+	unique := sorted[:0]
+	for idx, update := range sorted {
+		if idx+1 < len(sorted) && sorted[idx+1].Index == update.Index {
+			continue
+		}
+		unique = append(unique, update)
+	}
+
+	// move the not updated elements to the beginning, they stay sorted
+	writeIdx, updateIdx := 0, 0
+	for readIdx := range s {
+		if updateIdx < len(unique) && unique[updateIdx].Index == readIdx {
+			updateIdx++
+			continue
+		}
+		s[writeIdx] = s[readIdx]
+		writeIdx++
+	}
+	for _, update := range unique {
+		s[writeIdx] = update.Value
+		writeIdx++
+	}
+
+	Appended(s, uint(len(unique)))
+}
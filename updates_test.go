@@ -0,0 +1,125 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"testing"
+)
+
+func testAppendedUpdates(t *testing.T, initial []byte, updates []Update[int]) {
+	s := make(intSlice, len(initial))
+	for idx, v := range initial {
+		s[idx] = int(v)
+	}
+	stdsort.Ints(s)
+
+	// the reference: replace, then sort
+	expected := make([]int, len(s))
+	copy(expected, s)
+	for _, update := range updates {
+		expected[update.Index] = update.Value
+	}
+	stdsort.Ints(expected)
+
+	t.Run(fmt.Sprintf("%v/%v", s, updates), func(t *testing.T) {
+		AppendedUpdates(s, updates)
+		if !intsEqual(expected, s) {
+			t.Fatalf("%v != %v", expected, s)
+		}
+	})
+}
+
+func TestAppendedUpdates(t *testing.T) {
+	testAppendedUpdates(t, []byte{}, nil)
+	testAppendedUpdates(t, []byte{1, 2, 3}, nil)
+	testAppendedUpdates(t, []byte{1, 2, 3}, []Update[int]{{Index: 0, Value: 5}})
+	testAppendedUpdates(t, []byte{1, 2, 3, 4, 5}, []Update[int]{{Index: 4, Value: 0}, {Index: 1, Value: 10}})
+	// the last update of an index wins
+	testAppendedUpdates(t, []byte{1, 2, 3, 4, 5}, []Update[int]{{Index: 2, Value: 0}, {Index: 2, Value: 7}, {Index: 0, Value: 3}})
+
+	initial := make([]byte, 1000)
+	rand.New(rand.NewSource(0)).Read(initial)
+	testAppendedUpdates(t, initial, []Update[int]{{Index: 999, Value: -1}, {Index: 0, Value: 1000}, {Index: 500, Value: 500}})
+
+	t.Run("out_of_range", func(t *testing.T) {
+		expectPanic(t, "out of range", func() {
+			AppendedUpdates(intSlice{1, 2}, []Update[int]{{Index: 2, Value: 0}})
+		})
+	})
+}
+
+func FuzzAppendedUpdates(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, updateBytes []byte) {
+		if len(initial) == 0 {
+			return
+		}
+		updates := make([]Update[int], len(updateBytes)/2)
+		for idx := range updates {
+			updates[idx] = Update[int]{
+				Index: int(updateBytes[2*idx]) % len(initial),
+				Value: int(updateBytes[2*idx+1]),
+			}
+		}
+		testAppendedUpdates(t, initial, updates)
+	})
+}
+
+func BenchmarkAppendedUpdates(b *testing.B) {
+	const (
+		totalSize = 65536
+		csCount   = 20
+	)
+	for _, updatesCount := range []int{16, 1024} {
+		rng := rand.New(rand.NewSource(0))
+		in := make([]int, totalSize)
+		for idx := range in {
+			in[idx] = rng.Intn(totalSize)
+		}
+		stdsort.Ints(in)
+		updates := make([]Update[int], updatesCount)
+		for idx := range updates {
+			updates[idx] = Update[int]{Index: rng.Intn(totalSize), Value: rng.Intn(totalSize)}
+		}
+
+		cs := make([]intSlice, csCount)
+		for idx := range cs {
+			cs[idx] = make([]int, totalSize)
+		}
+
+		for _, f := range []struct {
+			name string
+			fn   func(intSlice)
+		}{
+			{name: "ReplaceAndSort", fn: func(s intSlice) {
+				for _, update := range updates {
+					s[update.Index] = update.Value
+				}
+				Sort(s)
+			}},
+			{name: "AppendedUpdates", fn: func(s intSlice) {
+				AppendedUpdates(s, updates)
+			}},
+		} {
+			b.Run(fmt.Sprintf("total-%d/updates-%d/%s", totalSize, updatesCount, f.name), func(b *testing.B) {
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range cs {
+							copy(cs[idx], in)
+						}
+						b.StartTimer()
+					}
+					f.fn(cs[idx])
+				}
+			})
+		}
+	}
+}
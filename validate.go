@@ -0,0 +1,84 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+)
+
+const (
+	// validateLessMaxLength is the maximal length of a slice which is
+	// validated if ValidateLess is true.
+	validateLessMaxLength = 1024
+
+	// validateLessAllPairsMaxLength is the maximal length of a slice for
+	// which all the pairs are checked (instead of sampled pairs).
+	validateLessAllPairsMaxLength = 64
+
+	// validateLessSamplesPerElement is the amount of sampled pairs per
+	// element of the slice.
+	validateLessSamplesPerElement = 8
+)
+
+// ValidateLess enables checking of the Less function in Appended and
+// AppendedWithBuf: for slices not longer than 1024 elements
+// the comparator is checked for irreflexivity (`Less(i, i)` is false) and
+// antisymmetry (`Less(i, j)` and `Less(j, i)` are not both true) before
+// sorting. If the check fails, it panics with a description of the
+// violation.
+//
+// A Less function which is not a strict weak ordering may make Appended
+// to produce an unsorted output, so this is useful in tests to surface
+// comparator bugs. It is not recommended to enable it in production.
+//
+// It is not safe to modify ValidateLess concurrently with calls of
+// Appended; set it once on initialization.
+var ValidateLess bool
+
+// validateLess panics if Less of the slice violates irreflexivity or
+// antisymmetry (on all pairs for short slices or on pseudo-randomly
+// sampled pairs otherwise).
+func validateLess[E any, S Interface[E]](s S) {
+	validateLessSeq(stdInterface[E, S](s))
+}
+
+// validateLessSeq is the same as validateLess, but for any sequence.
+func validateLessSeq[Q sequence](s Q) {
+	length := s.Len()
+	if length > validateLessMaxLength {
+		return
+	}
+	for i := 0; i < length; i++ {
+		if s.Less(i, i) {
+			panic(fmt.Sprintf("Less is not irreflexive: Less(%d, %d) is true", i, i))
+		}
+	}
+
+	if length <= validateLessAllPairsMaxLength {
+		for i := 0; i < length; i++ {
+			for j := i + 1; j < length; j++ {
+				validateLessPair(s, i, j)
+			}
+		}
+		return
+	}
+
+	// xorshift, seeded with a constant to make the check reproducible
+	state := uint32(2463534242)
+	for sample := 0; sample < length*validateLessSamplesPerElement; sample++ {
+		state ^= state << 13
+		state ^= state >> 17
+		state ^= state << 5
+		i := sample % length
+		j := int(state % uint32(length))
+		validateLessPair(s, i, j)
+	}
+}
+
+func validateLessPair[Q sequence](s Q, i, j int) {
+	if s.Less(i, j) && s.Less(j, i) {
+		panic(fmt.Sprintf("Less is not antisymmetric: both Less(%d, %d) and Less(%d, %d) are true", i, j, j, i))
+	}
+}
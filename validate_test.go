@@ -0,0 +1,145 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	"math/rand"
+	stdsort "sort"
+	"strings"
+	"testing"
+)
+
+// reflexiveLessSlice is a broken Interface: its Less returns true
+// for equal elements.
+type reflexiveLessSlice []int
+
+func (s reflexiveLessSlice) Less(i, j int) bool { return s[i] <= s[j] }
+
+// notEqualLessSlice is a broken Interface: its Less returns true
+// for any different elements, no matter of the order.
+type notEqualLessSlice []int
+
+func (s notEqualLessSlice) Less(i, j int) bool { return s[i] != s[j] }
+
+func withValidateLess(t testing.TB) {
+	ValidateLess = true
+	t.Cleanup(func() {
+		ValidateLess = false
+	})
+}
+
+func expectPanic(t *testing.T, substr string, fn func()) {
+	t.Helper()
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("expected a panic")
+		}
+		if !strings.Contains(fmt.Sprint(r), substr) {
+			t.Fatalf("unexpected panic: %v", r)
+		}
+	}()
+	fn()
+}
+
+func testValidateLess(t *testing.T, initial []byte, tailLenght uint) {
+	s, leftStrs, rightStrs, testName := prepareTestCase(initial, tailLenght)
+	c := make([]int, len(s))
+	copy(c, s)
+	t.Run(testName, func(t *testing.T) {
+		withValidateLess(t)
+		Appended(intSlice(s), tailLenght)
+		stdsort.Ints(c)
+		if !intsEqual(c, s) {
+			t.Fatalf("%v != %v; testCase < %s , %s >", c, s, strings.Join(leftStrs, ","), strings.Join(rightStrs, ","))
+		}
+	})
+}
+
+func TestValidateLess(t *testing.T) {
+	testValidateLess(t, []byte{1, 3, 5, 7, 11, 13, 12, 6, 4, 8}, 4)
+	testValidateLess(t, []byte{0, 0, 2, 5, 8, 8, 9, 10, 10, 11, 11, 15, 11, 12, 8, 14}, 4)
+
+	for _, length := range []int{10, 1000} {
+		s := make([]int, length)
+		for idx := range s {
+			s[idx] = idx % 7
+		}
+		t.Run(fmt.Sprintf("length-%d", length), func(t *testing.T) {
+			withValidateLess(t)
+			t.Run("irreflexivity", func(t *testing.T) {
+				expectPanic(t, "not irreflexive", func() {
+					Appended(reflexiveLessSlice(append([]int{}, s...)), 1)
+				})
+			})
+			t.Run("antisymmetry", func(t *testing.T) {
+				expectPanic(t, "not antisymmetric", func() {
+					AppendedWithBuf(notEqualLessSlice(append([]int{}, s...)), make([]int, 1))
+				})
+			})
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		// does not panic, the result is just unspecified
+		Appended(notEqualLessSlice{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 2, 1}, 2)
+	})
+
+	t.Run("too_long", func(t *testing.T) {
+		withValidateLess(t)
+		Appended(notEqualLessSlice(make([]int, validateLessMaxLength+1)), 2)
+	})
+}
+
+func FuzzValidateLess(f *testing.F) {
+	f.Fuzz(func(t *testing.T, initial, _ []byte) {
+		tailLenght := uint(rand.Intn(len(initial) + 1))
+		testValidateLess(t, initial, tailLenght)
+	})
+}
+
+func BenchmarkValidateLess(b *testing.B) {
+	const csCount = 20
+	for _, totalSize := range []int{64, 1024} {
+		tailSize := totalSize / 16
+		rng := rand.New(rand.NewSource(0))
+		in := make([][]int, csCount)
+		for idx := range in {
+			in[idx] = make([]int, totalSize)
+			s := in[idx]
+			for idx := range s {
+				s[idx] = rng.Intn(totalSize)
+			}
+			stdsort.Ints(s[:totalSize-tailSize])
+		}
+
+		cs := make([]intSlice, csCount)
+		for idx := range cs {
+			cs[idx] = make([]int, totalSize)
+		}
+
+		for _, validate := range []bool{false, true} {
+			b.Run(fmt.Sprintf("total-%d/tail-%d/validate-%t", totalSize, tailSize, validate), func(b *testing.B) {
+				if validate {
+					withValidateLess(b)
+				}
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					idx := i % csCount
+					if idx == 0 {
+						b.StopTimer()
+						for idx := range cs {
+							copy(cs[idx], in[idx])
+						}
+						b.StartTimer()
+					}
+					Appended(cs[idx], uint(tailSize))
+				}
+			})
+		}
+	}
+}
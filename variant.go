@@ -0,0 +1,142 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+)
+
+// Variant is a sorting function of the package. See Variants and
+// ChooseVariant.
+type Variant int
+
+const (
+	// VariantSort is a full sort (`sort.Sort`), which does not use
+	// the knowledge about the sorted prefix.
+	VariantSort Variant = iota
+
+	// VariantAppended is Appended.
+	VariantAppended
+
+	// VariantAppendedWithBuf is AppendedWithBuf.
+	VariantAppendedWithBuf
+
+	// VariantStable is StableFunc.
+	VariantStable
+
+	// VariantHeap is AppendedHeap.
+	VariantHeap
+
+	// VariantRadix is AppendedStringRadix.
+	VariantRadix
+)
+
+// String implements fmt.Stringer.
+func (v Variant) String() string {
+	if info, ok := Variants[v]; ok {
+		return info.Name
+	}
+	return fmt.Sprintf("Variant(%d)", int(v))
+}
+
+// VariantInfo describes the characteristics of a Variant.
+//
+// n is the length of the slice and k is the length of the unsorted tail.
+type VariantInfo struct {
+	// Name is the name of the function.
+	Name string
+
+	// Time is the time complexity.
+	Time string
+
+	// Space is the space complexity (without the slice itself).
+	Space string
+
+	// Stable is true if the original order of equal elements is preserved.
+	Stable bool
+
+	// NeedsBuffer is true if the function requires a buffer of k elements.
+	NeedsBuffer bool
+
+	// OnlyStrings is true if the function sorts only slices of strings.
+	OnlyStrings bool
+
+	// Choosable is true if ChooseVariant may return the variant. The rest
+	// of the variants are better or worse depending on the data (not only
+	// on the sizes), so they can only be chosen by the caller.
+	Choosable bool
+}
+
+// Variants describes the characteristics of each Variant.
+var Variants = map[Variant]VariantInfo{
+	VariantSort: {
+		Name:      "Sort",
+		Time:      "O(n*ln(n))",
+		Space:     "O(ln(n))",
+		Choosable: true,
+	},
+	VariantAppended: {
+		Name:      "Appended",
+		Time:      "O(k*ln(n) + n + k^2)",
+		Space:     "O(1)",
+		Choosable: true,
+	},
+	VariantAppendedWithBuf: {
+		Name:        "AppendedWithBuf",
+		Time:        "O(k*ln(n) + n)",
+		Space:       "O(k)",
+		NeedsBuffer: true,
+		Choosable:   true,
+	},
+	VariantStable: {
+		Name:      "StableFunc",
+		Time:      "O(k*ln(k)*ln(k) + k*ln(n) + n + k^2)",
+		Space:     "O(1)",
+		Stable:    true,
+		Choosable: true,
+	},
+	VariantHeap: {
+		Name:  "AppendedHeap",
+		Time:  "O(k*ln(n) + n + k^2), for any input",
+		Space: "O(1)",
+	},
+	VariantRadix: {
+		Name:        "AppendedStringRadix",
+		Time:        "O(k*ln(n) + n + k^2), otherwise O(n*L)",
+		Space:       "O(L)",
+		OnlyStrings: true,
+	},
+}
+
+// ChooseVariant returns the fastest Variant for sorting a slice of length
+// totalSize with an unsorted tail of length tailSize. It uses the same
+// thresholds as the functions themselves: VariantAppended (or
+// VariantAppendedWithBuf) is returned only if it would merge the tail
+// (report StrategyGroupInsert to OnStrategy) unless the tail happens to be
+// after the prefix.
+//
+// haveBuffer reports if a buffer of tailSize elements is available, and
+// needStable reports if the original order of equal elements should be
+// preserved.
+//
+// Only the Choosable variants are returned. VariantHeap and VariantRadix
+// are not: VariantHeap is slower than VariantAppended on average (it only
+// guarantees the time for any input), and whether VariantRadix is faster
+// depends on the strings (their common prefixes), which are unknown here.
+func ChooseVariant(totalSize, tailSize uint, haveBuffer, needStable bool) Variant {
+	switch {
+	case needStable:
+		return VariantStable
+	case tailSize == 0:
+		// nothing to sort
+		return VariantAppended
+	case haveBuffer && appendedPolicy{shouldUse: shouldUseAppendedWithBuf}.shouldGroupInsert(totalSize, tailSize):
+		return VariantAppendedWithBuf
+	case appendedPolicy{}.shouldGroupInsert(totalSize, tailSize):
+		return VariantAppended
+	default:
+		return VariantSort
+	}
+}
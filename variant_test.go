@@ -0,0 +1,150 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsort
+
+import (
+	"fmt"
+	stdslices "slices"
+	"testing"
+)
+
+func TestChooseVariant(t *testing.T) {
+	for _, testCase := range []struct {
+		totalSize  uint
+		tailSize   uint
+		haveBuffer bool
+		needStable bool
+		expected   Variant
+	}{
+		{totalSize: 65536, tailSize: 16, haveBuffer: false, needStable: false, expected: VariantAppended},
+		{totalSize: 65536, tailSize: 16, haveBuffer: true, needStable: false, expected: VariantAppendedWithBuf},
+		{totalSize: 65536, tailSize: 16, haveBuffer: false, needStable: true, expected: VariantStable},
+		{totalSize: 65536, tailSize: 16, haveBuffer: true, needStable: true, expected: VariantStable},
+
+		{totalSize: 65536, tailSize: 16384, haveBuffer: false, needStable: false, expected: VariantSort},
+		{totalSize: 65536, tailSize: 16384, haveBuffer: true, needStable: false, expected: VariantAppendedWithBuf},
+		{totalSize: 65536, tailSize: 16384, haveBuffer: false, needStable: true, expected: VariantStable},
+		{totalSize: 65536, tailSize: 16384, haveBuffer: true, needStable: true, expected: VariantStable},
+
+		{totalSize: 65536, tailSize: 65536, haveBuffer: false, needStable: false, expected: VariantSort},
+		{totalSize: 65536, tailSize: 65536, haveBuffer: true, needStable: false, expected: VariantSort},
+		{totalSize: 65536, tailSize: 65536, haveBuffer: false, needStable: true, expected: VariantStable},
+		{totalSize: 65536, tailSize: 65536, haveBuffer: true, needStable: true, expected: VariantStable},
+
+		// below MinAppendedSize
+		{totalSize: 8, tailSize: 1, haveBuffer: false, needStable: false, expected: VariantSort},
+		{totalSize: 8, tailSize: 1, haveBuffer: true, needStable: false, expected: VariantSort},
+
+		{totalSize: 16, tailSize: 1, haveBuffer: false, needStable: false, expected: VariantAppended},
+		{totalSize: 16, tailSize: 1, haveBuffer: true, needStable: false, expected: VariantAppendedWithBuf},
+		{totalSize: 15, tailSize: 1, haveBuffer: true, needStable: false, expected: VariantSort},
+
+		// nothing to sort
+		{totalSize: 16, tailSize: 0, haveBuffer: false, needStable: false, expected: VariantAppended},
+		{totalSize: 16, tailSize: 0, haveBuffer: true, needStable: false, expected: VariantAppended},
+		{totalSize: 16, tailSize: 0, haveBuffer: false, needStable: true, expected: VariantStable},
+	} {
+		t.Run(fmt.Sprintf("%d/%d/%t/%t", testCase.totalSize, testCase.tailSize, testCase.haveBuffer, testCase.needStable), func(t *testing.T) {
+			v := ChooseVariant(testCase.totalSize, testCase.tailSize, testCase.haveBuffer, testCase.needStable)
+			if v != testCase.expected {
+				t.Fatalf("%s != %s", v, testCase.expected)
+			}
+			info := Variants[v]
+			if info.NeedsBuffer && !testCase.haveBuffer {
+				t.Fatalf("%s requires a buffer", v)
+			}
+			if testCase.needStable && !info.Stable {
+				t.Fatalf("%s is not stable", v)
+			}
+		})
+	}
+}
+
+func TestChooseVariantMatchesStrategy(t *testing.T) {
+	var strategies []string
+	OnStrategy = func(strategy string, totalSize, tailSize uint) {
+		strategies = append(strategies, strategy)
+	}
+	defer func() { OnStrategy = nil }()
+
+	for totalSize := uint(1); totalSize <= 300; totalSize++ {
+		for tailSize := uint(1); tailSize <= totalSize; tailSize++ {
+			// the tail is less than the prefix, so the tail is never
+			// just sorted (StrategySortTail)
+			s := make(intSlice, totalSize)
+			for idx := range s {
+				s[idx] = idx
+			}
+			for idx := totalSize - tailSize; idx < totalSize; idx++ {
+				s[idx] = -int(idx)
+			}
+			buf := make([]int, tailSize)
+
+			for _, haveBuffer := range []bool{false, true} {
+				strategies = strategies[:0]
+				v := ChooseVariant(totalSize, tailSize, haveBuffer, false)
+				sCopy := stdslices.Clone(s)
+				switch v {
+				case VariantAppendedWithBuf:
+					AppendedWithBuf(sCopy, buf)
+				default:
+					Appended(sCopy, tailSize)
+				}
+				if len(strategies) != 1 {
+					t.Fatalf("%d/%d/%t: unexpected strategies %v", totalSize, tailSize, haveBuffer, strategies)
+				}
+				if (v != VariantSort) != (strategies[0] == StrategyGroupInsert) {
+					t.Fatalf("%d/%d/%t: %s, but %s", totalSize, tailSize, haveBuffer, v, strategies[0])
+				}
+			}
+		}
+	}
+}
+
+func TestVariants(t *testing.T) {
+	for v := VariantSort; v <= VariantRadix; v++ {
+		info, ok := Variants[v]
+		if !ok {
+			t.Fatalf("no info for %d", int(v))
+		}
+		if info.Name == "" || info.Time == "" || info.Space == "" {
+			t.Fatalf("incomplete info for %d: %+v", int(v), info)
+		}
+		if v.String() != info.Name {
+			t.Fatalf("%s != %s", v, info.Name)
+		}
+	}
+	if !Variants[VariantRadix].OnlyStrings || Variants[VariantHeap].OnlyStrings {
+		t.Fatal("unexpected OnlyStrings")
+	}
+	if Variants[VariantRadix].Choosable || Variants[VariantHeap].Choosable {
+		t.Fatal("unexpected Choosable")
+	}
+
+	for totalSize := uint(0); totalSize < 1024; totalSize++ {
+		for tailSize := uint(0); tailSize <= totalSize; tailSize++ {
+			for _, flags := range [][2]bool{{false, false}, {false, true}, {true, false}, {true, true}} {
+				if v := ChooseVariant(totalSize, tailSize, flags[0], flags[1]); !Variants[v].Choosable {
+					t.Fatalf("%d/%d/%v: %s", totalSize, tailSize, flags, v)
+				}
+			}
+		}
+	}
+}
+
+func TestVariantString(t *testing.T) {
+	if s := VariantAppendedWithBuf.String(); s != "AppendedWithBuf" {
+		t.Fatalf("unexpected name: %s", s)
+	}
+	if s := VariantHeap.String(); s != "AppendedHeap" {
+		t.Fatalf("unexpected name: %s", s)
+	}
+	if s := VariantRadix.String(); s != "AppendedStringRadix" {
+		t.Fatalf("unexpected name: %s", s)
+	}
+	if s := Variant(-1).String(); s != "Variant(-1)" {
+		t.Fatalf("unexpected name: %s", s)
+	}
+}
@@ -0,0 +1,40 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsorttest
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// RandomAppendedSlice returns a slice of n random values in range [0, n),
+// where the first `n-tail` values are sorted (like after appending tail
+// elements to a sorted slice), and the tail length to be passed to
+// xsort.Appended. The result is deterministic for the same seed.
+func RandomAppendedSlice(n, tail int, seed int64) ([]int, uint) {
+	if n < 0 || tail < 0 || tail > n {
+		panic(fmt.Sprintf("invalid lengths: n == %d, tail == %d", n, tail))
+	}
+	rng := rand.New(rand.NewSource(seed))
+	s := make([]int, n)
+	for idx := range s {
+		s[idx] = rng.Intn(n)
+	}
+	sort.Ints(s[:n-tail])
+	return s, uint(tail)
+}
+
+// CheckSorted reports an error through t if s is not sorted in ascending
+// order.
+func CheckSorted(t TB, s []int) {
+	t.Helper()
+	for idx := 1; idx < len(s); idx++ {
+		if s[idx] < s[idx-1] {
+			t.Errorf("not sorted at index %d: %d < %d", idx, s[idx], s[idx-1])
+			return
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsorttest
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRandomAppendedSlice(t *testing.T) {
+	for _, testCase := range [][2]int{{0, 0}, {1, 1}, {100, 0}, {100, 10}, {100, 100}} {
+		n, tail := testCase[0], testCase[1]
+		t.Run(fmt.Sprintf("n-%d/tail-%d", n, tail), func(t *testing.T) {
+			s, tailLength := RandomAppendedSlice(n, tail, 1)
+			if len(s) != n || tailLength != uint(tail) {
+				t.Fatalf("unexpected lengths: %d, %d", len(s), tailLength)
+			}
+			CheckSorted(t, s[:n-tail])
+
+			again, _ := RandomAppendedSlice(n, tail, 1)
+			for idx := range s {
+				if s[idx] != again[idx] {
+					t.Fatalf("not deterministic: %v != %v", s, again)
+				}
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected a panic")
+			}
+		}()
+		RandomAppendedSlice(1, 2, 0)
+	})
+}
+
+func TestCheckSorted(t *testing.T) {
+	for _, testCase := range []struct {
+		s        []int
+		expected bool
+	}{
+		{nil, true},
+		{[]int{1}, true},
+		{[]int{1, 1, 2}, true},
+		{[]int{2, 1}, false},
+		{[]int{1, 2, 3, 0}, false},
+	} {
+		r := &recorder{}
+		CheckSorted(r, testCase.s)
+		if (len(r.errors) == 0) != testCase.expected {
+			t.Fatalf("%v: unexpected errors: %v", testCase.s, r.errors)
+		}
+	}
+}
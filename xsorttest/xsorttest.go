@@ -0,0 +1,87 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+// Package xsorttest provides utilities for testing code built on top of
+// package xsort.
+package xsorttest
+
+import (
+	"github.com/go-ng/sort"
+	"github.com/go-ng/xsort"
+)
+
+// TB is the subset of `testing.TB` used by this package (so the package
+// does not depend on package `testing`).
+type TB interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// AssertSameResult sorts copies of input using xsort.Appended,
+// xsort.AppendedWithBuf and sort.Sort (the first `len(input)-tailLength`
+// elements of input are expected to be already sorted), and reports
+// an error through t if:
+//
+// * any of the results is not sorted;
+// * any of the results is not a permutation of input;
+// * the results differ from each other (elements equivalent in terms of
+// Less are considered the same, since the sorts are not stable).
+//
+// It is a differential test, which allows to safely change
+// the internals of the algorithms.
+func AssertSameResult[E comparable, S xsort.Interface[E]](t TB, input S, tailLength uint) {
+	t.Helper()
+
+	type result struct {
+		name string
+		s    S
+	}
+	results := []result{
+		{name: "Appended"},
+		{name: "AppendedWithBuf"},
+		{name: "sort.Sort"},
+	}
+	for idx := range results {
+		results[idx].s = append(S(nil), input...)
+	}
+	xsort.Appended(results[0].s, tailLength)
+	xsort.AppendedWithBuf(results[1].s, make([]E, tailLength))
+	sort.Sort(results[2].s)
+
+	inputCounts := map[E]int{}
+	for _, v := range input {
+		inputCounts[v]++
+	}
+	for _, r := range results {
+		for idx := 1; idx < len(r.s); idx++ {
+			if r.s.Less(idx, idx-1) {
+				t.Errorf("%s: the result is not sorted at index %d: %v", r.name, idx, r.s)
+				return
+			}
+		}
+
+		counts := map[E]int{}
+		for _, v := range r.s {
+			counts[v]++
+		}
+		for v, count := range inputCounts {
+			if counts[v] != count {
+				t.Errorf("%s: the result is not a permutation of the input: %v occurs %d times instead of %d", r.name, v, counts[v], count)
+				return
+			}
+		}
+	}
+
+	reference := results[len(results)-1]
+	for _, r := range results[:len(results)-1] {
+		merged := append(append(S(nil), r.s...), reference.s...)
+		for idx := range r.s {
+			refIdx := len(r.s) + idx
+			if merged.Less(idx, refIdx) || merged.Less(refIdx, idx) {
+				t.Errorf("%s and %s differ at index %d: %v != %v", r.name, reference.name, idx, r.s[idx], reference.s[idx])
+				return
+			}
+		}
+	}
+}
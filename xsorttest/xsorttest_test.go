@@ -0,0 +1,70 @@
+// This file is available under CC-0 1.0 license.
+//
+// See file `CC0-LICENSE`.
+
+package xsorttest
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+type keySeq struct {
+	Key, Seq int
+}
+
+type keySeqs []keySeq
+
+func (s keySeqs) Less(i, j int) bool {
+	return s[i].Key < s[j].Key
+}
+
+// brokenLess is an Interface, which Less does not define a strict weak
+// ordering, so the sorting results are expected to be different.
+type brokenLess []int
+
+func (s brokenLess) Less(i, j int) bool {
+	return s[i]%3 < s[j]
+}
+
+// recorder is a TB recording the reported errors.
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertSameResult(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	for _, tailLength := range []uint{0, 1, 10, 100, 1000} {
+		s := make(keySeqs, 1000)
+		for idx := range s {
+			s[idx] = keySeq{Key: rng.Intn(100), Seq: idx}
+		}
+		prefix := s[:len(s)-int(tailLength)]
+		sort.Slice(prefix, func(i, j int) bool {
+			return prefix[i].Key < prefix[j].Key
+		})
+		t.Run(fmt.Sprintf("tail-%d", tailLength), func(t *testing.T) {
+			AssertSameResult(t, s, tailLength)
+		})
+	}
+
+	t.Run("broken", func(t *testing.T) {
+		s := make(brokenLess, 100)
+		for idx := range s {
+			s[idx] = rng.Intn(10)
+		}
+		r := &recorder{}
+		AssertSameResult(r, s, uint(len(s)))
+		if len(r.errors) == 0 {
+			t.Fatal("expected errors")
+		}
+	})
+}